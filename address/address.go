@@ -30,10 +30,16 @@ type Address interface {
 }
 
 func NewAddress(raw string) (addr Address, err error) {
-	var data []byte
+	var (
+		hrp  string
+		data []byte
+	)
 
 	if strings.HasPrefix(raw, "addr") || strings.HasPrefix(raw, "stake") {
-		_, data, err = bech32.Decode(raw)
+		hrp, data, err = bech32.Decode(raw)
+	} else if bHrp, bData, bErr := bech32.Decode(raw); bErr == nil {
+		// bech32 address using a prefix registered for a custom network
+		hrp, data = bHrp, bData
 	} else {
 		data = base58.Decode(raw)
 	}
@@ -42,7 +48,12 @@ func NewAddress(raw string) (addr Address, err error) {
 		return
 	}
 
-	return NewAddressFromBytes(data)
+	if hrp == "" {
+		return NewAddressFromBytes(data)
+	}
+	return newAddressFromBytes(data, func(id byte) *network.NetworkInfo {
+		return network.FromPrefix(hrp, id)
+	})
 }
 
 func NewAddressFromHex(hexAddr string) (addr Address, err error) {
@@ -55,13 +66,16 @@ func NewAddressFromHex(hexAddr string) (addr Address, err error) {
 }
 
 func NewAddressFromBytes(data []byte) (addr Address, err error) {
-	header := data[0]
-	netId := header & 0x0F
+	return newAddressFromBytes(data, network.FromNetworkId)
+}
 
-	networks := map[byte]network.NetworkInfo{
-		byte(1): *network.MainNet(),
-		byte(0): *network.TestNet(),
+// newAddressFromBytes decodes raw address bytes, resolving the network id of the header with networkOf.
+func newAddressFromBytes(data []byte, networkOf func(id byte) *network.NetworkInfo) (addr Address, err error) {
+	if len(data) == 0 {
+		return nil, ErrUnsupportedAddress
 	}
+	header := data[0]
+	netId := header & 0x0F
 
 	switch (header & 0xF0) >> 4 {
 	// 1000: byron address
@@ -80,8 +94,15 @@ func NewAddressFromBytes(data []byte) (addr Address, err error) {
 	// 0010: base address: keyhash28,scripthash28
 	// 0011: base address: scripthash28,scripthash28
 	case 0b0000, 0b0001, 0b0010, 0b0011:
+		const baseAddrSize = 1 + 28 + 28
+		if len(data) < baseAddrSize {
+			return nil, errors.New("cbor not enough error")
+		}
+		if len(data) > baseAddrSize {
+			return nil, errors.New("cbor trailing data error")
+		}
 		baseAddr := BaseAddress{
-			Network: networks[netId],
+			Network: *networkOf(netId),
 			Payment: *readAddrCred(data, header, 4, 1),
 			Stake:   *readAddrCred(data, header, 5, 1+28),
 		}
@@ -117,7 +138,7 @@ func NewAddressFromBytes(data []byte) (addr Address, err error) {
 			return nil, errors.New("cbor trailing data error")
 		}
 
-		res := NewPointerAddress(networks[netId], *paymentCred, *NewPointer(slot, txIndex, certIndex))
+		res := NewPointerAddress(*networkOf(netId), *paymentCred, *NewPointer(slot, txIndex, certIndex))
 		return res, nil
 
 	// 0110: enterprise address: keyhash28
@@ -132,8 +153,7 @@ func NewAddressFromBytes(data []byte) (addr Address, err error) {
 		if len(data) > enterpriseAddrSize {
 			return nil, errors.New("cbor trailing data error")
 		}
-		netw := networks[netId]
-		res := NewEnterpriseAddress(&netw, readAddrCred(data, header, 4, 1))
+		res := NewEnterpriseAddress(networkOf(netId), readAddrCred(data, header, 4, 1))
 		return res, nil
 	case 0b1110, 0b1111:
 		const rewardAddrSize = 1 + 28
@@ -143,8 +163,7 @@ func NewAddressFromBytes(data []byte) (addr Address, err error) {
 		if len(data) > rewardAddrSize {
 			return nil, errors.New("cbor trailing data error")
 		}
-		netw := networks[netId]
-		res := NewRewardAddress(&netw, readAddrCred(data, header, 4, 1))
+		res := NewRewardAddress(networkOf(netId), readAddrCred(data, header, 4, 1))
 		return res, nil
 
 	default:
@@ -1,6 +1,7 @@
 package address_test
 
 import (
+	"bytes"
 	"errors"
 	"flag"
 	"reflect"
@@ -121,3 +122,92 @@ func TestAddressInit(t *testing.T) {
 		})
 	}
 }
+
+func TestGovernanceId(t *testing.T) {
+	hash := bytes.Repeat([]byte{0x02}, 28)
+	scenarios := []struct {
+		description string
+		id          *address.GovernanceId
+		bech32      string
+	}{
+		{
+			description: "drep key hash",
+			id:          address.NewDRepId(address.NewKeyStakeCredential(hash)),
+			bech32:      "drep1ygpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqsahpxyl",
+		},
+		{
+			description: "drep script hash",
+			id:          address.NewDRepId(address.NewScriptStakeCredential(hash)),
+			bech32:      "drep1yvpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqsa93hyc",
+		},
+		{
+			description: "committee hot key hash",
+			id:          address.NewCommitteeHotId(address.NewKeyStakeCredential(hash)),
+			bech32:      "cc_hot1qgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqs0rnvhj",
+		},
+		{
+			description: "committee cold script hash",
+			id:          address.NewCommitteeColdId(address.NewScriptStakeCredential(hash)),
+			bech32:      "cc_cold1zvpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqsedhpdu",
+		},
+	}
+
+	for _, sc := range scenarios {
+		t.Run(sc.description, func(t *testing.T) {
+			assert.Equal(t, sc.bech32, sc.id.String())
+
+			id, err := address.NewGovernanceId(sc.bech32)
+			assert.NoError(t, err)
+			assert.Equal(t, sc.id, id)
+		})
+	}
+
+	legacy, err := address.NewGovernanceId("drep1qgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqy8rs5fa")
+	assert.NoError(t, err)
+	assert.Equal(t, address.NewDRepId(address.NewKeyStakeCredential(hash)), legacy)
+
+	_, err = address.NewGovernanceId("stake_test1uqevw2xnsc0pvn9t9r9c7qryfqfeerchgrlm3ea2nefr9hqp8n5xl")
+	assert.Error(t, err)
+
+	txHash, index, err := address.DecodeGovActionId("gov_action1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqpzklpgpf")
+	assert.NoError(t, err)
+	assert.Equal(t, make([]byte, 32), txHash)
+	assert.Equal(t, uint16(17), index)
+
+	govAction, err := address.EncodeGovActionId(txHash, 257)
+	assert.NoError(t, err)
+	txHash, index, err = address.DecodeGovActionId(govAction)
+	assert.NoError(t, err)
+	assert.Equal(t, make([]byte, 32), txHash)
+	assert.Equal(t, uint16(257), index)
+}
+
+func TestCustomNetworkAddress(t *testing.T) {
+	devnet := network.Network{
+		Name:          "address-devnet",
+		Info:          network.NetworkInfo{NetworkId: 0, ProtocolMagic: 4242},
+		AddressPrefix: "addr_dev",
+		StakePrefix:   "stake_dev",
+	}
+	if err := network.Register(devnet); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { network.Unregister(devnet.Name) })
+
+	hash := bytes.Repeat([]byte{0x02}, 28)
+	for _, addr := range []address.Address{
+		address.NewEnterpriseAddress(&devnet.Info, address.NewKeyStakeCredential(hash)),
+		address.NewBaseAddress(&devnet.Info, address.NewKeyStakeCredential(hash), address.NewKeyStakeCredential(hash)),
+		address.NewRewardAddress(&devnet.Info, address.NewKeyStakeCredential(hash)),
+	} {
+		decoded, err := address.NewAddress(addr.String())
+		assert.NoError(t, err)
+		assert.Equal(t, addr.String(), decoded.String())
+		assert.Equal(t, devnet.Info, *decoded.NetworkInfo())
+	}
+
+	// Default prefixes keep resolving network id 0 to the legacy testnet.
+	decoded, err := address.NewAddress("addr_test1qqe92py4mf3ffrtmjuwjpzu6jwlw0zmr50h8ey67qcehlmty5kcrvg2ds9fkpg32t535l9v6lkgaj5cunufgvz5f7snql2fawd")
+	assert.NoError(t, err)
+	assert.Equal(t, *network.TestNet(), *decoded.NetworkInfo())
+}
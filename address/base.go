@@ -36,12 +36,10 @@ func (b *BaseAddress) NetworkInfo() *network.NetworkInfo {
 	return &(b.Network)
 }
 
-// Prefix returns the string prefix for the base address. Prefix `addr` for mainnet addresses and `addr_test` for testnet.
+// Prefix returns the string prefix for the base address. Prefix `addr` for mainnet addresses, `addr_test` for testnets
+// or the address prefix registered for a custom network.
 func (b *BaseAddress) Prefix() string {
-	if b.Network.NetworkId == network.TestNet().NetworkId {
-		return "addr_test"
-	}
-	return "addr"
+	return b.Network.AddressPrefix()
 }
 
 // MarshalCBOR returns a cbor encoded byte slice of the base address.
@@ -5,8 +5,17 @@ import (
 	"hash/crc32"
 
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
 	"github.com/fivebinaries/go-cardano-serialization/network"
 	"github.com/fxamacker/cbor/v2"
+	"golang.org/x/crypto/sha3"
+)
+
+// Byron address types stored in the Tag of a ByronAddress.
+const (
+	ByronPubKeyAddressType uint = iota
+	ByronScriptAddressType
+	ByronRedeemAddressType
 )
 
 var (
@@ -16,7 +25,8 @@ var (
 
 type ByronAddressAttributes struct {
 	Payload []byte `cbor:"1,keyasint,omitempty"`
-	Network *uint8 `cbor:"2,keyasint,omitempty"`
+	// Network holds the cbor encoded protocol magic of addresses on networks other than mainnet.
+	Network []byte `cbor:"2,keyasint,omitempty"`
 }
 
 type ByronAddress struct {
@@ -41,7 +51,18 @@ func (b *ByronAddress) NetworkInfo() (ni *network.NetworkInfo) {
 	if b.Attributes.Network == nil {
 		return network.MainNet()
 	}
-	return network.TestNet()
+
+	var magic uint32
+	if err := cbor.Unmarshal(b.Attributes.Network, &magic); err != nil {
+		return network.TestNet()
+	}
+	if net, err := network.ByMagic(magic); err == nil {
+		return &net.Info
+	}
+	return &network.NetworkInfo{
+		NetworkId:     network.TestNet().NetworkId,
+		ProtocolMagic: magic,
+	}
 }
 
 // MarshalCBOR returns a cbor encoded byte slice of the base address.
@@ -91,8 +112,8 @@ func (b *ByronAddress) UnmarshalCBOR(data []byte) error {
 		return err
 	}
 
-	if len(byron.Hashed) != 28 || byron.Tag != 0 {
-		return errors.New("")
+	if len(byron.Hashed) != 28 || byron.Tag > ByronRedeemAddressType {
+		return ErrInvalidByronAddress
 	}
 
 	*b = ByronAddress{
@@ -104,6 +125,37 @@ func (b *ByronAddress) UnmarshalCBOR(data []byte) error {
 	return nil
 }
 
+// NewByronRedeemAddress returns a pointer to a new Byron redeem address, as used for AVVM balances
+// in the byron genesis, given the network and the 32 byte redeem verification key.
+func NewByronRedeemAddress(net *network.NetworkInfo, redeemKey []byte) (*ByronAddress, error) {
+	if len(redeemKey) != crypto.PublicKeyLen {
+		return nil, ErrInvalidByronAddress
+	}
+
+	var attrs ByronAddressAttributes
+	if !net.IsMainNet() {
+		magic, err := cbor.Marshal(net.ProtocolMagic)
+		if err != nil {
+			return nil, err
+		}
+		attrs.Network = magic
+	}
+
+	spendingData := []interface{}{ByronRedeemAddressType, redeemKey}
+	raw, err := cbor.Marshal([]interface{}{ByronRedeemAddressType, spendingData, attrs})
+	if err != nil {
+		return nil, err
+	}
+	sha := sha3.Sum256(raw)
+	root := crypto.Blake2b224(sha[:])
+
+	return &ByronAddress{
+		Hash:       root[:],
+		Attributes: attrs,
+		Tag:        ByronRedeemAddressType,
+	}, nil
+}
+
 //Pref returns the string prefix for the base address. "" for byron address since it has no prefix.
 func (b *ByronAddress) Prefix() string {
 	return ""
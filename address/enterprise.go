@@ -32,12 +32,10 @@ func (e *EnterpriseAddress) NetworkInfo() *network.NetworkInfo {
 	return &(e.Network)
 }
 
-// Prefix returns the string prefix for the Enterprise Address. Prefix `addr` for mainnet addresses and `addr_test` for testnet.
+// Prefix returns the string prefix for the Enterprise Address. Prefix `addr` for mainnet addresses, `addr_test` for testnets
+// or the address prefix registered for a custom network.
 func (e *EnterpriseAddress) Prefix() string {
-	if e.Network.NetworkId == network.TestNet().NetworkId {
-		return "addr_test"
-	}
-	return "addr"
+	return e.Network.AddressPrefix()
 }
 
 // MarshalCBOR returns a cbor encoded byte slice of the enterprise address.
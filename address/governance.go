@@ -0,0 +1,166 @@
+package address
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/fivebinaries/go-cardano-serialization/internal/bech32"
+)
+
+var (
+	ErrInvalidGovernanceId = errors.New("invalid governance identifier")
+)
+
+// GovernanceIdType is the role of a governance credential, stored in the upper nibble of the CIP-129 header byte.
+type GovernanceIdType byte
+
+const (
+	CommitteeHotGovernanceIdType GovernanceIdType = iota
+	CommitteeColdGovernanceIdType
+	DRepGovernanceIdType
+)
+
+const (
+	// CIP-129 credential types stored in the lower nibble of the header byte.
+	keyHashGovernanceCredential    = 0b0010
+	scriptHashGovernanceCredential = 0b0011
+
+	govActionPrefix = "gov_action"
+	txHashLen       = 32
+)
+
+var governanceIdPrefixes = map[GovernanceIdType]string{
+	CommitteeHotGovernanceIdType:  "cc_hot",
+	CommitteeColdGovernanceIdType: "cc_cold",
+	DRepGovernanceIdType:          "drep",
+}
+
+// GovernanceId is a CIP-129 identifier of a delegate representative or constitutional committee credential.
+type GovernanceId struct {
+	Kind       GovernanceIdType
+	Credential StakeCredential
+}
+
+// NewDRepId returns a pointer to a new GovernanceId for the credential of a delegate representative.
+func NewDRepId(cred *StakeCredential) *GovernanceId {
+	return &GovernanceId{Kind: DRepGovernanceIdType, Credential: *cred}
+}
+
+// NewCommitteeHotId returns a pointer to a new GovernanceId for the hot credential of a committee member.
+func NewCommitteeHotId(cred *StakeCredential) *GovernanceId {
+	return &GovernanceId{Kind: CommitteeHotGovernanceIdType, Credential: *cred}
+}
+
+// NewCommitteeColdId returns a pointer to a new GovernanceId for the cold credential of a committee member.
+func NewCommitteeColdId(cred *StakeCredential) *GovernanceId {
+	return &GovernanceId{Kind: CommitteeColdGovernanceIdType, Credential: *cred}
+}
+
+// NewGovernanceId returns a pointer to a new GovernanceId decoded from a bech32 `drep`, `cc_hot` or `cc_cold` string.
+// Legacy CIP-105 `drep` identifiers without a header byte are accepted as key hash credentials.
+func NewGovernanceId(raw string) (*GovernanceId, error) {
+	hrp, data, err := bech32.Decode(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if hrp == governanceIdPrefixes[DRepGovernanceIdType] && len(data) == 28 {
+		return NewDRepId(NewKeyStakeCredential(data)), nil
+	}
+
+	id, err := NewGovernanceIdFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	if id.Prefix() != hrp {
+		return nil, ErrInvalidGovernanceId
+	}
+
+	return id, nil
+}
+
+// NewGovernanceIdFromBytes returns a pointer to a new GovernanceId from the header byte and credential hash.
+func NewGovernanceIdFromBytes(data []byte) (*GovernanceId, error) {
+	if len(data) != 29 {
+		return nil, ErrInvalidGovernanceId
+	}
+
+	kind := GovernanceIdType(data[0] >> 4)
+	if _, ok := governanceIdPrefixes[kind]; !ok {
+		return nil, ErrInvalidGovernanceId
+	}
+
+	hash := make([]byte, 28)
+	copy(hash, data[1:])
+
+	switch data[0] & 0x0F {
+	case keyHashGovernanceCredential:
+		return &GovernanceId{Kind: kind, Credential: *NewKeyStakeCredential(hash)}, nil
+	case scriptHashGovernanceCredential:
+		return &GovernanceId{Kind: kind, Credential: *NewScriptStakeCredential(hash)}, nil
+	}
+
+	return nil, ErrInvalidGovernanceId
+}
+
+// Bytes returns the header byte followed by the credential hash.
+func (g *GovernanceId) Bytes() []byte {
+	data := make([]byte, 29)
+	data[0] = byte(g.Kind)<<4 | keyHashGovernanceCredential
+	if g.Credential.Kind == ScriptStakeCredentialType {
+		data[0] = byte(g.Kind)<<4 | scriptHashGovernanceCredential
+	}
+	copy(data[1:], g.Credential.Payload)
+	return data
+}
+
+// String returns the bech32 encoded CIP-129 identifier.
+func (g *GovernanceId) String() string {
+	str, _ := bech32.Encode(g.Prefix(), g.Bytes())
+
+	return str
+}
+
+// Prefix returns the bech32 prefix of the identifier, `drep`, `cc_hot` or `cc_cold`.
+func (g *GovernanceId) Prefix() string {
+	return governanceIdPrefixes[g.Kind]
+}
+
+// EncodeGovActionId returns the bech32 `gov_action` identifier of the governance action
+// proposed by the transaction txHash at index.
+func EncodeGovActionId(txHash []byte, index uint16) (string, error) {
+	if len(txHash) != txHashLen {
+		return "", ErrInvalidGovernanceId
+	}
+
+	data := append([]byte{}, txHash...)
+	if index > 0xFF {
+		data = append(data, byte(index>>8), byte(index))
+	} else {
+		data = append(data, byte(index))
+	}
+
+	return bech32.Encode(govActionPrefix, data)
+}
+
+// DecodeGovActionId returns the transaction hash and index of a bech32 `gov_action` identifier.
+func DecodeGovActionId(raw string) (txHash []byte, index uint16, err error) {
+	hrp, data, err := bech32.Decode(raw)
+	if err != nil {
+		return
+	}
+	if hrp != govActionPrefix {
+		return nil, 0, ErrInvalidGovernanceId
+	}
+
+	switch len(data) {
+	case txHashLen + 1:
+		index = uint16(data[txHashLen])
+	case txHashLen + 2:
+		index = binary.BigEndian.Uint16(data[txHashLen:])
+	default:
+		return nil, 0, ErrInvalidGovernanceId
+	}
+
+	return data[:txHashLen], index, nil
+}
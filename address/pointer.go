@@ -80,12 +80,10 @@ func (p *PointerAddress) String() string {
 	return str
 }
 
-// Prefix returns the string prefix for the base address. Prefix `addr` for mainnet addresses and `addr_test` for testnet.
+// Prefix returns the string prefix for the base address. Prefix `addr` for mainnet addresses, `addr_test` for testnets
+// or the address prefix registered for a custom network.
 func (p *PointerAddress) Prefix() string {
-	if p.Network == *network.TestNet() {
-		return "addr_test"
-	}
-	return "addr"
+	return p.Network.AddressPrefix()
 }
 
 // NetworkInfo returns NetworkInfo{ProtocolMagigic and NetworkId}.
@@ -30,7 +30,7 @@ func (r *RewardAddress) String() string {
 
 // MarshalCBOR returns a cbor encoded byte slice of the base address.
 func (r *RewardAddress) MarshalCBOR() ([]byte, error) {
-	return cbor.Marshal(r.Bytes)
+	return cbor.Marshal(r.Bytes())
 }
 
 // NetworkInfo returns pointer to NetworkInfo{ProtocolMagigic and NetworkId}.
@@ -38,12 +38,10 @@ func (r *RewardAddress) NetworkInfo() *network.NetworkInfo {
 	return &(r.Network)
 }
 
-// Prefix returns the string prefix for the base address. Prefix `stake` for mainnet addresses and `stake_test` for testnet.
+// Prefix returns the string prefix for the base address. Prefix `stake` for mainnet addresses, `stake_test` for testnets
+// or the stake prefix registered for a custom network.
 func (r *RewardAddress) Prefix() string {
-	if r.Network.NetworkId == network.TestNet().NetworkId {
-		return "stake_test"
-	}
-	return "stake"
+	return r.Network.StakePrefix()
 }
 
 // NewRewardAddress returns a pointer to a new RewardAddress given the network and stake credentials.
@@ -1,5 +1,15 @@
 package address
 
+import (
+	"errors"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+var (
+	ErrInvalidStakeCredential = errors.New("invalid stake credential")
+)
+
 type StakeCredentialType byte
 
 const (
@@ -25,3 +35,27 @@ func NewScriptStakeCredential(hash []byte) *StakeCredential {
 		Payload: hash,
 	}
 }
+
+// MarshalCBOR returns the cbor encoding of the credential as used in certificates, `[kind, hash]`.
+func (s StakeCredential) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{s.Kind, s.Payload})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded `[kind, hash]` credential into a StakeCredential.
+func (s *StakeCredential) UnmarshalCBOR(data []byte) error {
+	var raw struct {
+		_       struct{} `cbor:",toarray"`
+		Kind    StakeCredentialType
+		Payload []byte
+	}
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Kind > ScriptStakeCredentialType || len(raw.Payload) != 28 {
+		return ErrInvalidStakeCredential
+	}
+
+	s.Kind = raw.Kind
+	s.Payload = raw.Payload
+	return nil
+}
@@ -1,6 +1,7 @@
 package bip32
 
 import (
+	"crypto/ed25519"
 	"crypto/hmac"
 	"crypto/sha256"
 	"crypto/sha512"
@@ -17,6 +18,11 @@ const (
 	SignatureLength = 64
 )
 
+var (
+	ErrHardenedPublicDerivation = errors.New("hardened indices cannot be derived from a public key")
+	ErrInvalidXPub              = errors.New("invalid extended public key")
+)
+
 type XPrv []byte
 type XPub []byte
 type PublicKey []byte
@@ -153,6 +159,54 @@ func (pub XPub) ChainCode() PrivateKey {
 	return PrivateKey(pub[32:])
 }
 
+// Derive returns the soft child of the extended public key at the index, matching the public key of XPrv.Derive.
+// Hardened indices require the private key and return ErrHardenedPublicDerivation.
+func (pub XPub) Derive(index uint32) (XPub, error) {
+	if isHardened(index) {
+		return nil, ErrHardenedPublicDerivation
+	}
+	if len(pub) != 64 {
+		return nil, ErrInvalidXPub
+	}
+
+	var pk [crypto.PublicKeyLen]byte
+	copy(pk[:], pub.PublicKey())
+	var point edwards25519.ExtendedGroupElement
+	if !point.FromBytes(&pk) {
+		return nil, ErrInvalidXPub
+	}
+
+	serializedIndex := make([]byte, 4)
+	binary.LittleEndian.PutUint32(serializedIndex, index)
+
+	zmac := hmac.New(sha512.New, pub.ChainCode())
+	zmac.Write([]byte{0x02})
+	zmac.Write(pk[:])
+	zmac.Write(serializedIndex)
+	imac := hmac.New(sha512.New, pub.ChainCode())
+	imac.Write([]byte{0x03})
+	imac.Write(pk[:])
+	imac.Write(serializedIndex)
+
+	zout := zmac.Sum(nil)
+	iout := imac.Sum(nil)
+
+	// A_i = A + [8 * Z_L[:28]]B
+	var zl8 [32]byte
+	copy(zl8[:], add28mul8(make([]byte, 32), zout[:32]))
+	one := [32]byte{1}
+	var child edwards25519.ProjectiveGroupElement
+	edwards25519.GeDoubleScalarMultVartime(&child, &one, &point, &zl8)
+
+	var childKey [crypto.PublicKeyLen]byte
+	child.ToBytes(&childKey)
+
+	out := make([]byte, 0, 64)
+	out = append(out, childKey[:]...)
+	out = append(out, iout[32:]...)
+	return out, nil
+}
+
 //implements https://github.com/Emurgo/cardano-serialization-lib/blob/0e89deadf9183a129b9a25c0568eed177d6c6d7c/rust/src/chain_crypto/derive.rs#L30
 //implements https://github.com/Emurgo/cardano-serialization-lib/blob/0e89deadf9183a129b9a25c0568eed177d6c6d7c/rust/src/crypto.rs#L123
 func FromBip39Entropy(entropy []byte, password []byte) XPrv {
@@ -172,6 +226,15 @@ func (pub PublicKey) Hash() crypto.Ed25519KeyHash {
 	return crypto.Blake2b224(pub)
 }
 
+// Verify reports whether signature is a valid Ed25519 signature of message by the public key.
+// Signatures of extended private keys made by XPrv.Sign are standard Ed25519 signatures.
+func (pub PublicKey) Verify(message, signature []byte) bool {
+	if len(pub) != crypto.PublicKeyLen || len(signature) != SignatureLength {
+		return false
+	}
+	return ed25519.Verify(ed25519.PublicKey(pub), message, signature)
+}
+
 func MakePublicKey(extendedSecret []byte) [crypto.PublicKeyLen]byte {
 	var result [crypto.PublicKeyLen]byte
 	var key [crypto.PublicKeyLen]byte
@@ -0,0 +1,172 @@
+package bip32_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/fivebinaries/go-cardano-serialization/network"
+	"github.com/stretchr/testify/assert"
+)
+
+func harden(num uint) uint32 {
+	return uint32(0x80000000 + num)
+}
+
+func createAccountKey() bip32.XPrv {
+	rootKey := bip32.FromBip39Entropy(
+		[]byte{214, 64, 138, 69, 145, 210, 32, 51, 202, 45, 90, 151, 33, 194, 153, 176, 188, 94, 94, 186, 67, 118, 194, 227, 207, 157, 54, 49, 34, 12, 83, 93},
+		[]byte{},
+	)
+	return rootKey.Derive(harden(1852)).Derive(harden(1815)).Derive(harden(0))
+}
+
+func TestXPubDerive(t *testing.T) {
+	accountKey := createAccountKey()
+	accountPub := accountKey.Public()
+
+	for _, path := range [][2]uint32{{0, 0}, {0, 1}, {1, 7}, {2, 0}, {0, 0x7fffffff}} {
+		expected := accountKey.Derive(path[0]).Derive(path[1]).Public()
+
+		chain, err := accountPub.Derive(path[0])
+		assert.NoError(t, err)
+		child, err := chain.Derive(path[1])
+		assert.NoError(t, err)
+		assert.Equal(t, expected, child)
+	}
+
+	_, err := accountPub.Derive(harden(0))
+	assert.ErrorIs(t, err, bip32.ErrHardenedPublicDerivation)
+	_, err = bip32.XPub(accountPub[:32]).Derive(0)
+	assert.ErrorIs(t, err, bip32.ErrInvalidXPub)
+}
+
+func TestXPubBaseAddress(t *testing.T) {
+	accountKey := createAccountKey()
+	accountPub := accountKey.Public()
+
+	paymentHash := accountKey.Derive(0).Derive(0).Public().PublicKey().Hash()
+	stakeHash := accountKey.Derive(2).Derive(0).Public().PublicKey().Hash()
+	expected := address.NewBaseAddress(network.MainNet(), address.NewKeyStakeCredential(paymentHash[:]), address.NewKeyStakeCredential(stakeHash[:]))
+
+	external, err := accountPub.Derive(0)
+	assert.NoError(t, err)
+	payment, err := external.Derive(0)
+	assert.NoError(t, err)
+	staking, err := accountPub.Derive(2)
+	assert.NoError(t, err)
+	stake, err := staking.Derive(0)
+	assert.NoError(t, err)
+
+	paymentHash = payment.PublicKey().Hash()
+	stakeHash = stake.PublicKey().Hash()
+	addr := address.NewBaseAddress(network.MainNet(), address.NewKeyStakeCredential(paymentHash[:]), address.NewKeyStakeCredential(stakeHash[:]))
+	assert.Equal(t, expected.String(), addr.String())
+}
+
+func TestPublicKeyVerify(t *testing.T) {
+	key := createAccountKey().Derive(0).Derive(0)
+	message := []byte("message")
+	signature := key.Sign(message)
+
+	pub := key.Public().PublicKey()
+	assert.True(t, pub.Verify(message, signature[:]))
+	assert.False(t, pub.Verify([]byte("other message"), signature[:]))
+	assert.False(t, pub.Verify(message, signature[:32]))
+
+	other := createAccountKey().Derive(0).Derive(1).Public().PublicKey()
+	assert.False(t, other.Verify(message, signature[:]))
+}
+
+func TestMasterKeySchemes(t *testing.T) {
+	// CIP-3 Icarus test vectors.
+	entropy, _ := bip32.MnemonicToEntropy("eight country switch draw meat scout mystery blade tip drift useless good keep usage title", bip32.English)
+	assert.Equal(t,
+		"c065afd2832cd8b087c4d9ab7011f481ee1e0721e78ea5dd609f3ab3f156d245d176bd8fd4ec60b4731c3918a2a72a0226c0cd119ec35b47e4d55884667f552a23f7fdcd4a10c6cd2c7393ac61d877873e248f417634aa3d812af327ffe9d620",
+		hex.EncodeToString(bip32.FromBip39Entropy(entropy, nil)))
+	assert.Equal(t,
+		"70531039904019351e1afb361cd1b312a4d0565d4ff9f8062d38acf4b15cce41d7b5738d9c893feea55512a3004acb0d222c35d3e3d5cde943a15a9824cbac59443cf67e589614076ba01e354b1a432e0e6db3b59e37fc56b5fb0222970a010e",
+		hex.EncodeToString(bip32.FromBip39Entropy(entropy, []byte("foo"))))
+
+	// Icarus and Icarus-Trezor only differ for 24 word mnemonics.
+	assert.Equal(t, bip32.FromBip39Entropy(entropy, []byte("foo")), bip32.FromIcarusTrezorEntropy(entropy, []byte("foo")))
+
+	// For 24 words Trezor devices include the checksum byte of the mnemonic in the entropy, as CIP-3 describes.
+	entropy = []byte{214, 64, 138, 69, 145, 210, 32, 51, 202, 45, 90, 151, 33, 194, 153, 176, 188, 94, 94, 186, 67, 118, 194, 227, 207, 157, 54, 49, 34, 12, 83, 93}
+	checksum := sha256.Sum256(entropy)
+	assert.Equal(t,
+		bip32.FromBip39Entropy(append(append([]byte{}, entropy...), checksum[0]), nil),
+		bip32.FromIcarusTrezorEntropy(entropy, nil))
+	assert.NotEqual(t, bip32.FromBip39Entropy(entropy, nil), bip32.FromIcarusTrezorEntropy(entropy, nil))
+}
+
+func TestLedgerMasterKey(t *testing.T) {
+	// CIP-3 test vector.
+	key, err := bip32.FromLedgerMnemonic("recall grace sport punch exhibit mad harbor stand obey short width stem awkward used stairs wool ugly trap season stove worth toward congress jaguar", nil)
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"a08cf85b564ecf3b947d8d4321fb96d70ee7bb760877e371899b14e2ccf88658104b884682b57efd97decbb318a45c05a527b9cc5c2f64f7352935a049ceea60680d52308194ccef2a18e6812b452a5815fbd7f5babc083856919aaf668fe7e4",
+		hex.EncodeToString(key))
+
+	key, err = bip32.FromLedgerMnemonic("recall grace sport punch exhibit mad harbor stand obey short width stem awkward used stairs wool ugly trap season stove worth toward congress jaguar", []byte("foo"))
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"488b13cdf56ed4ced9b2bcd61924ca7a81d317810d4ddbbd8ab3e86431289a58e0e3f635ab873a97f046c77bca4203419125293c9ab6d97a252a07353fdfa05055209a1e929b4223692cbf6900d41e8b42d14449ede56dbab8c4c7fd981e139a",
+		hex.EncodeToString(key))
+
+	// The master secret of this mnemonic is hashed again four times.
+	key, err = bip32.FromLedgerMnemonic(strings.Repeat("abandon ", 11)+"about", nil)
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"402b03cd9c8bed9ba9f9bd6cd9c315ce9fcc59c7c25d37c85a36096617e69d418e35cb4a3b737afd007f0688618f21a8831643c0e6c77fc33c06026d2a0fc93832596435e70647d7d98ef102a32ea40319ca8fb6c851d7346d3bd8f9d1492658",
+		hex.EncodeToString(key))
+
+	_, err = bip32.FromLedgerMnemonic(strings.Repeat("abandon ", 12), nil)
+	assert.Error(t, err)
+}
+
+func TestByronMasterKey(t *testing.T) {
+	for _, entropy := range [][]byte{make([]byte, 16), []byte("0202020202020202")} {
+		key, err := bip32.ByronMasterKey(entropy)
+		assert.NoError(t, err)
+		assert.Len(t, key, bip32.XPrv_Size)
+
+		// The scalar is clamped and its third highest bit is cleared by retrying the root seed chain.
+		assert.Zero(t, key[0]&0b0000_0111)
+		assert.Equal(t, byte(0b0100_0000), key[31]&0b1110_0000)
+
+		again, err := bip32.ByronMasterKey(entropy)
+		assert.NoError(t, err)
+		assert.Equal(t, key, again)
+		assert.NotEqual(t, bip32.FromBip39Entropy(entropy, nil), key)
+	}
+}
+
+func TestByronDerivation(t *testing.T) {
+	root, err := bip32.ByronMasterKey(make([]byte, 16))
+	assert.NoError(t, err)
+
+	account := root.DeriveByron(harden(0))
+	assert.Len(t, account, bip32.XPrv_Size)
+	assert.NotEqual(t, root.Derive(harden(0)), account)
+
+	for _, index := range []uint32{0, 1, harden(0), harden(14)} {
+		child := account.DeriveByron(index)
+		assert.Equal(t, child, account.DeriveByron(index))
+
+		message := []byte("byron")
+		signature := child.Sign(message)
+		assert.True(t, child.Public().PublicKey().Verify(message, signature[:]))
+
+		pub, err := account.Public().DeriveByron(index)
+		if index >= harden(0) {
+			assert.ErrorIs(t, err, bip32.ErrHardenedPublicDerivation)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, child.Public(), pub)
+	}
+}
@@ -0,0 +1,233 @@
+package bip32
+
+import (
+	"errors"
+
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fivebinaries/go-cardano-serialization/internal/bech32"
+)
+
+// Bech32 prefixes of extended private keys as defined in CIP-5.
+const (
+	RootXPrvPrefix          = "root_xsk"
+	AccountXPrvPrefix       = "acct_xsk"
+	AddrXPrvPrefix          = "addr_xsk"
+	StakeXPrvPrefix         = "stake_xsk"
+	DRepXPrvPrefix          = "drep_xsk"
+	CommitteeColdXPrvPrefix = "cc_cold_xsk"
+	CommitteeHotXPrvPrefix  = "cc_hot_xsk"
+)
+
+// Bech32 prefixes of extended public keys as defined in CIP-5.
+const (
+	RootXPubPrefix          = "root_xvk"
+	AccountXPubPrefix       = "acct_xvk"
+	AddrXPubPrefix          = "addr_xvk"
+	StakeXPubPrefix         = "stake_xvk"
+	DRepXPubPrefix          = "drep_xvk"
+	CommitteeColdXPubPrefix = "cc_cold_xvk"
+	CommitteeHotXPubPrefix  = "cc_hot_xvk"
+)
+
+// Bech32 prefixes of public keys as defined in CIP-5.
+const (
+	RootPublicKeyPrefix          = "root_vk"
+	AccountPublicKeyPrefix       = "acct_vk"
+	AddrPublicKeyPrefix          = "addr_vk"
+	StakePublicKeyPrefix         = "stake_vk"
+	DRepPublicKeyPrefix          = "drep_vk"
+	CommitteeColdPublicKeyPrefix = "cc_cold_vk"
+	CommitteeHotPublicKeyPrefix  = "cc_hot_vk"
+)
+
+const xpubSize = 64
+
+var (
+	ErrInvalidPrefix    = errors.New("unexpected bech32 prefix")
+	ErrInvalidKeyLength = errors.New("unexpected key length")
+)
+
+// decodeBech32 returns the data of a bech32 string after checking its prefix and the data length.
+func decodeBech32(raw string, prefix string, size int) ([]byte, error) {
+	hrp, data, err := bech32.Decode(raw)
+	if err != nil {
+		return nil, err
+	}
+	if hrp != prefix {
+		return nil, ErrInvalidPrefix
+	}
+	if len(data) != size {
+		return nil, ErrInvalidKeyLength
+	}
+	return data, nil
+}
+
+// Bech32 returns the bech32 encoding of the key with the prefix of its role, e.g. AddrXPrvPrefix.
+// XPrv has no String method as its role is unknown, see WithRole.
+func (key XPrv) Bech32(prefix string) (string, error) {
+	return bech32.Encode(prefix, key)
+}
+
+// XPrvFromBech32 returns the extended private key of a bech32 string with the prefix, e.g. `root_xsk`.
+func XPrvFromBech32(raw string, prefix string) (XPrv, error) {
+	return decodeBech32(raw, prefix, XPrv_Size)
+}
+
+// Bech32 returns the bech32 encoding of the key with the prefix of its role, e.g. StakeXPubPrefix.
+func (pub XPub) Bech32(prefix string) (string, error) {
+	return bech32.Encode(prefix, pub)
+}
+
+// XPubFromBech32 returns the extended public key of a bech32 string with the prefix, e.g. `acct_xvk`.
+func XPubFromBech32(raw string, prefix string) (XPub, error) {
+	return decodeBech32(raw, prefix, xpubSize)
+}
+
+// Bech32 returns the bech32 encoding of the key with the prefix of its role, e.g. StakePublicKeyPrefix.
+func (pub PublicKey) Bech32(prefix string) (string, error) {
+	return bech32.Encode(prefix, pub)
+}
+
+// PublicKeyFromBech32 returns the public key of a bech32 string with the prefix, e.g. `stake_vk`.
+func PublicKeyFromBech32(raw string, prefix string) (PublicKey, error) {
+	return decodeBech32(raw, prefix, crypto.PublicKeyLen)
+}
+
+// Role is the CIP-5 role of a key, which chooses the prefix of its bech32 encoding.
+type Role uint8
+
+const (
+	RootRole Role = iota
+	AccountRole
+	AddrRole
+	StakeRole
+	DRepRole
+	CommitteeColdRole
+	CommitteeHotRole
+)
+
+// rolePrefixes are the prefixes of the extended private, extended public and public keys of each role.
+var rolePrefixes = map[Role][3]string{
+	RootRole:          {RootXPrvPrefix, RootXPubPrefix, RootPublicKeyPrefix},
+	AccountRole:       {AccountXPrvPrefix, AccountXPubPrefix, AccountPublicKeyPrefix},
+	AddrRole:          {AddrXPrvPrefix, AddrXPubPrefix, AddrPublicKeyPrefix},
+	StakeRole:         {StakeXPrvPrefix, StakeXPubPrefix, StakePublicKeyPrefix},
+	DRepRole:          {DRepXPrvPrefix, DRepXPubPrefix, DRepPublicKeyPrefix},
+	CommitteeColdRole: {CommitteeColdXPrvPrefix, CommitteeColdXPubPrefix, CommitteeColdPublicKeyPrefix},
+	CommitteeHotRole:  {CommitteeHotXPrvPrefix, CommitteeHotXPubPrefix, CommitteeHotPublicKeyPrefix},
+}
+
+// Indices of the kinds of keys in rolePrefixes.
+const (
+	xprvKind = iota
+	xpubKind
+	publicKeyKind
+)
+
+// prefix returns the bech32 prefix of the kind of key of the role.
+func (r Role) prefix(kind int) string {
+	return rolePrefixes[r][kind]
+}
+
+// parseRole returns the role and data of a bech32 string with the prefix of the kind of key of any role.
+func parseRole(raw string, kind int, size int) (Role, []byte, error) {
+	hrp, _, err := bech32.Decode(raw)
+	if err != nil {
+		return 0, nil, err
+	}
+	for role, prefixes := range rolePrefixes {
+		if prefixes[kind] == hrp {
+			data, err := decodeBech32(raw, hrp, size)
+			return role, data, err
+		}
+	}
+	return 0, nil, ErrInvalidPrefix
+}
+
+// RoleXPrv is an extended private key with its role, e.g. the root key of a wallet or a payment key.
+type RoleXPrv struct {
+	XPrv
+	Role Role
+}
+
+// WithRole returns the key with the role choosing its bech32 prefix.
+func (key XPrv) WithRole(role Role) RoleXPrv {
+	return RoleXPrv{XPrv: key, Role: role}
+}
+
+// String returns the bech32 encoding of the key with the prefix of its role, e.g. `root_xsk`.
+func (key RoleXPrv) String() string {
+	str, _ := key.Bech32(key.Role.prefix(xprvKind))
+	return str
+}
+
+// Public returns the extended public key of the key with the same role.
+func (key RoleXPrv) Public() RoleXPub {
+	return key.XPrv.Public().WithRole(key.Role)
+}
+
+// ParseXPrv returns the extended private key of a bech32 string with the prefix of any role, e.g. `addr_xsk`.
+func ParseXPrv(raw string) (RoleXPrv, error) {
+	role, data, err := parseRole(raw, xprvKind, XPrv_Size)
+	if err != nil {
+		return RoleXPrv{}, err
+	}
+	return XPrv(data).WithRole(role), nil
+}
+
+// RoleXPub is an extended public key with its role, e.g. an account key shared with a watch-only wallet.
+type RoleXPub struct {
+	XPub
+	Role Role
+}
+
+// WithRole returns the key with the role choosing its bech32 prefix.
+func (pub XPub) WithRole(role Role) RoleXPub {
+	return RoleXPub{XPub: pub, Role: role}
+}
+
+// String returns the bech32 encoding of the key with the prefix of its role, e.g. `acct_xvk`.
+func (pub RoleXPub) String() string {
+	str, _ := pub.Bech32(pub.Role.prefix(xpubKind))
+	return str
+}
+
+// PublicKey returns the public key of the key with the same role.
+func (pub RoleXPub) PublicKey() RolePublicKey {
+	return pub.XPub.PublicKey().WithRole(pub.Role)
+}
+
+// ParseXPub returns the extended public key of a bech32 string with the prefix of any role, e.g. `acct_xvk`.
+func ParseXPub(raw string) (RoleXPub, error) {
+	role, data, err := parseRole(raw, xpubKind, xpubSize)
+	if err != nil {
+		return RoleXPub{}, err
+	}
+	return XPub(data).WithRole(role), nil
+}
+
+// RolePublicKey is a public key with its role, e.g. a stake verification key.
+type RolePublicKey struct {
+	PublicKey
+	Role Role
+}
+
+// WithRole returns the key with the role choosing its bech32 prefix.
+func (pub PublicKey) WithRole(role Role) RolePublicKey {
+	return RolePublicKey{PublicKey: pub, Role: role}
+}
+
+// String returns the bech32 encoding of the key with the prefix of its role, e.g. `stake_vk`.
+func (pub RolePublicKey) String() string {
+	str, _ := pub.Bech32(pub.Role.prefix(publicKeyKind))
+	return str
+}
+
+// ParsePublicKey returns the public key of a bech32 string with the prefix of any role, e.g. `stake_vk`.
+func ParsePublicKey(raw string) (RolePublicKey, error) {
+	role, data, err := parseRole(raw, publicKeyKind, crypto.PublicKeyLen)
+	if err != nil {
+		return RolePublicKey{}, err
+	}
+	return PublicKey(data).WithRole(role), nil
+}
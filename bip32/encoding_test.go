@@ -0,0 +1,85 @@
+package bip32_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyBech32(t *testing.T) {
+	rootKey, err := bip32.FromMnemonic("test walk nut penalty hip pave soap entry language right filter choice", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accountKey := rootKey.Derive(harden(1852)).Derive(harden(1815)).Derive(harden(0))
+	paymentKey := accountKey.Derive(0).Derive(0)
+
+	// Payment verification key of the CIP-19 test vectors.
+	assert.Equal(t, "addr_vk1w0l2sr2zgfm26ztc6nl9xy8ghsk5sh6ldwemlpmp9xylzy4dtf7st80zhd", paymentKey.WithRole(bip32.AddrRole).Public().PublicKey().String())
+	assert.Equal(t, "acct_xvk1eame4ge0x5yrwpuqs5eyw89kfmjpgfkfh02xzdx6c2k9k2swcr5clf0u634tm82x6nv2j750x3j7938g70ya4k0lv6pr59s7etw2vpqgfmule", accountKey.WithRole(bip32.AccountRole).Public().String())
+
+	encoded := rootKey.WithRole(bip32.RootRole).String()
+	assert.True(t, strings.HasPrefix(encoded, "root_xsk1"))
+	xprv, err := bip32.XPrvFromBech32(encoded, bip32.RootXPrvPrefix)
+	assert.NoError(t, err)
+	assert.Equal(t, rootKey, xprv)
+
+	encoded, err = paymentKey.Bech32(bip32.AddrXPrvPrefix)
+	assert.NoError(t, err)
+	xprv, err = bip32.XPrvFromBech32(encoded, bip32.AddrXPrvPrefix)
+	assert.NoError(t, err)
+	assert.Equal(t, paymentKey, xprv)
+
+	stakeKey := accountKey.Derive(2).Derive(0).Public()
+	encoded, err = stakeKey.Bech32(bip32.StakeXPubPrefix)
+	assert.NoError(t, err)
+	xpub, err := bip32.XPubFromBech32(encoded, bip32.StakeXPubPrefix)
+	assert.NoError(t, err)
+	assert.Equal(t, stakeKey, xpub)
+
+	encoded, err = stakeKey.PublicKey().Bech32(bip32.StakePublicKeyPrefix)
+	assert.NoError(t, err)
+	pub, err := bip32.PublicKeyFromBech32(encoded, bip32.StakePublicKeyPrefix)
+	assert.NoError(t, err)
+	assert.Equal(t, stakeKey.PublicKey(), pub)
+
+	_, err = bip32.XPubFromBech32(encoded, bip32.StakeXPubPrefix)
+	assert.Equal(t, bip32.ErrInvalidPrefix, err)
+
+	encoded, err = stakeKey.PublicKey().Bech32(bip32.StakeXPubPrefix)
+	assert.NoError(t, err)
+	_, err = bip32.XPubFromBech32(encoded, bip32.StakeXPubPrefix)
+	assert.Equal(t, bip32.ErrInvalidKeyLength, err)
+}
+
+func TestParseRoleKeys(t *testing.T) {
+	rootKey, err := bip32.FromMnemonic("test walk nut penalty hip pave soap entry language right filter choice", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stakeKey := rootKey.Derive(harden(1852)).Derive(harden(1815)).Derive(harden(0)).Derive(2).Derive(0)
+
+	for _, role := range []bip32.Role{bip32.RootRole, bip32.AccountRole, bip32.AddrRole, bip32.StakeRole, bip32.DRepRole, bip32.CommitteeColdRole, bip32.CommitteeHotRole} {
+		xprv := stakeKey.WithRole(role)
+		parsed, err := bip32.ParseXPrv(xprv.String())
+		assert.NoError(t, err)
+		assert.Equal(t, xprv, parsed)
+
+		xpub, err := bip32.ParseXPub(xprv.Public().String())
+		assert.NoError(t, err)
+		assert.Equal(t, xprv.Public(), xpub)
+
+		pub, err := bip32.ParsePublicKey(xpub.PublicKey().String())
+		assert.NoError(t, err)
+		assert.Equal(t, xpub.PublicKey(), pub)
+	}
+
+	pub := stakeKey.Public().PublicKey().WithRole(bip32.StakeRole)
+	assert.True(t, strings.HasPrefix(pub.String(), "stake_vk1"))
+	_, err = bip32.ParseXPub(pub.String())
+	assert.Equal(t, bip32.ErrInvalidPrefix, err)
+	_, err = bip32.ParseXPrv("addr_test1vz2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzerspjrlsz")
+	assert.Equal(t, bip32.ErrInvalidPrefix, err)
+}
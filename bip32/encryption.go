@@ -0,0 +1,69 @@
+package bip32
+
+import (
+	"crypto/sha512"
+	"crypto/subtle"
+	"errors"
+
+	"golang.org/x/crypto/chacha20"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Parameters of the encrypted keys of cardano-crypto, used by cardano-wallet and Daedalus.
+const (
+	EncryptedXPrvSize = 128
+
+	legacyEncryptionSalt  = "encrypted wallet salt"
+	legacyEncryptionNonce = "serokellfore"
+	legacyEncryptionIter  = 15000
+)
+
+var (
+	ErrInvalidEncryptedKey = errors.New("encrypted key must be 128 bytes")
+	ErrWrongPassphrase     = errors.New("wrong passphrase for the encrypted key")
+)
+
+// legacyStream xors data with the ChaCha20 stream keyed with the passphrase. Empty passphrases leave data unencrypted.
+func legacyStream(passphrase, data []byte) []byte {
+	out := make([]byte, len(data))
+	if len(passphrase) == 0 {
+		copy(out, data)
+		return out
+	}
+
+	key := pbkdf2.Key(passphrase, []byte(legacyEncryptionSalt), legacyEncryptionIter, chacha20.KeySize, sha512.New)
+	cipher, err := chacha20.NewUnauthenticatedCipher(key, []byte(legacyEncryptionNonce))
+	if err != nil {
+		panic(err)
+	}
+	cipher.XORKeyStream(out, data)
+	return out
+}
+
+// EncryptLegacy returns the key in the cardano-crypto encrypted format of cardano-wallet and Daedalus,
+// the extended private key encrypted with the passphrase followed by the public key and chain code.
+func (key XPrv) EncryptLegacy(passphrase []byte) []byte {
+	pk := key.publicKey()
+
+	out := make([]byte, 0, EncryptedXPrvSize)
+	out = append(out, legacyStream(passphrase, key.extendedPrivateKey())...)
+	out = append(out, pk[:]...)
+	out = append(out, key.ChainCode()...)
+	return out
+}
+
+// DecryptLegacy returns the extended private key of a key in the cardano-crypto encrypted format.
+// A passphrase which does not decrypt the key to its public key returns ErrWrongPassphrase.
+func DecryptLegacy(encrypted []byte, passphrase []byte) (XPrv, error) {
+	if len(encrypted) != EncryptedXPrvSize {
+		return nil, ErrInvalidEncryptedKey
+	}
+
+	extended := legacyStream(passphrase, encrypted[:64])
+	pk := MakePublicKey(extended)
+	if subtle.ConstantTimeCompare(pk[:], encrypted[64:96]) != 1 {
+		return nil, ErrWrongPassphrase
+	}
+
+	return append(extended, encrypted[96:]...), nil
+}
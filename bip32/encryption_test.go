@@ -0,0 +1,48 @@
+package bip32_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLegacyEncryption(t *testing.T) {
+	key, err := bip32.FromMnemonic("test walk nut penalty hip pave soap entry language right filter choice", nil)
+	assert.NoError(t, err)
+
+	encrypted := key.EncryptLegacy([]byte("password"))
+	assert.Equal(t,
+		"e7191cbb50f5fb6e553f4433dd38d99fbd0ba4712b10018ebb182c79847327d2d3040ce5d94143887bc0fdcb6a0401487a4886e44d041fd583d4bdbbd9bf060d"+
+			hex.EncodeToString(key.Public()),
+		hex.EncodeToString(encrypted))
+
+	decrypted, err := bip32.DecryptLegacy(encrypted, []byte("password"))
+	assert.NoError(t, err)
+	assert.Equal(t, key, decrypted)
+
+	_, err = bip32.DecryptLegacy(encrypted, []byte("wrong"))
+	assert.ErrorIs(t, err, bip32.ErrWrongPassphrase)
+	_, err = bip32.DecryptLegacy(encrypted[:96], []byte("password"))
+	assert.ErrorIs(t, err, bip32.ErrInvalidEncryptedKey)
+
+	// Keys are stored unencrypted with an empty passphrase.
+	assert.Equal(t, []byte(key[:64]), key.EncryptLegacy(nil)[:64])
+}
+
+func TestDerivationPath(t *testing.T) {
+	path, err := bip32.ParseDerivationPath("m/1852'/1815H/0'/2/0")
+	assert.NoError(t, err)
+	assert.Equal(t, bip32.DerivationPath{harden(1852), harden(1815), harden(0), 2, 0}, path)
+	assert.Equal(t, "m/1852'/1815'/0'/2/0", path.String())
+
+	rootKey, err := bip32.FromMnemonic("test walk nut penalty hip pave soap entry language right filter choice", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, rootKey.Derive(harden(1852)).Derive(harden(1815)).Derive(harden(0)).Derive(2).Derive(0), rootKey.DerivePath(path))
+
+	for _, invalid := range []string{"", "1852'/0", "m/", "m/-1", "m/2147483648", "m/0''"} {
+		_, err = bip32.ParseDerivationPath(invalid)
+		assert.ErrorIs(t, err, bip32.ErrInvalidDerivationPath, invalid)
+	}
+}
@@ -0,0 +1,168 @@
+package bip32
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	ErrInvalidMnemonicLength   = errors.New("mnemonic must have 12, 15, 18, 21 or 24 words")
+	ErrInvalidEntropyLength    = errors.New("entropy must be 16, 20, 24, 28 or 32 bytes")
+	ErrUnknownMnemonicWord     = errors.New("mnemonic word is not in the wordlist")
+	ErrInvalidMnemonicChecksum = errors.New("invalid mnemonic checksum")
+)
+
+// Wordlist is a BIP-39 list of 2048 mnemonic words.
+type Wordlist struct {
+	words     []string
+	indices   map[string]int
+	separator string
+}
+
+func newWordlist(words []string, separator string) *Wordlist {
+	indices := make(map[string]int, len(words))
+	for i, word := range words {
+		indices[norm.NFKD.String(word)] = i
+	}
+	return &Wordlist{words: words, indices: indices, separator: separator}
+}
+
+// BIP-39 wordlists. Mnemonics are NFKD normalized before their words are looked up, so words may be given
+// in the composed or decomposed form, e.g. the Japanese が or か followed by a combining ゙.
+var (
+	English            = newWordlist(wordlists.English, " ")
+	Japanese           = newWordlist(wordlists.Japanese, "　")
+	Korean             = newWordlist(wordlists.Korean, " ")
+	Spanish            = newWordlist(wordlists.Spanish, " ")
+	ChineseSimplified  = newWordlist(wordlists.ChineseSimplified, " ")
+	ChineseTraditional = newWordlist(wordlists.ChineseTraditional, " ")
+	French             = newWordlist(wordlists.French, " ")
+	Italian            = newWordlist(wordlists.Italian, " ")
+	Czech              = newWordlist(wordlists.Czech, " ")
+)
+
+// Wordlists are the supported wordlists in the order FromMnemonic detects them.
+var Wordlists = []*Wordlist{English, Japanese, Korean, Spanish, ChineseSimplified, ChineseTraditional, French, Italian, Czech}
+
+const (
+	mnemonicWordBits = 11
+	minEntropyLen    = 16
+	maxEntropyLen    = 32
+)
+
+// NewMnemonic returns a new mnemonic of the number of words from the wordlist, generated with secure randomness.
+func NewMnemonic(words int, wordlist *Wordlist) (string, error) {
+	if words%3 != 0 || words < 12 || words > 24 {
+		return "", ErrInvalidMnemonicLength
+	}
+
+	entropy := make([]byte, words*4/3)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return MnemonicFromEntropy(entropy, wordlist)
+}
+
+// MnemonicFromEntropy returns the mnemonic encoding the entropy with words from the wordlist.
+func MnemonicFromEntropy(entropy []byte, wordlist *Wordlist) (string, error) {
+	if len(entropy)%4 != 0 || len(entropy) < minEntropyLen || len(entropy) > maxEntropyLen {
+		return "", ErrInvalidEntropyLength
+	}
+
+	// The checksum is the first len(entropy)/4 bits of the hash, at most one byte.
+	checksum := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), checksum[0])
+
+	words := make([]string, (len(entropy)*8+len(entropy)/4)/mnemonicWordBits)
+	for i := range words {
+		var index int
+		for bit := i * mnemonicWordBits; bit < (i+1)*mnemonicWordBits; bit++ {
+			index = index<<1 | int(data[bit/8]>>(7-bit%8)&1)
+		}
+		words[i] = wordlist.words[index]
+	}
+	return strings.Join(words, wordlist.separator), nil
+}
+
+// MnemonicToEntropy returns the entropy encoded by the mnemonic after validating its checksum.
+func MnemonicToEntropy(mnemonic string, wordlist *Wordlist) ([]byte, error) {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if len(words)%3 != 0 || len(words) < 12 || len(words) > 24 {
+		return nil, ErrInvalidMnemonicLength
+	}
+
+	data := make([]byte, (len(words)*mnemonicWordBits+7)/8)
+	for i, word := range words {
+		index, ok := wordlist.indices[word]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownMnemonicWord, word)
+		}
+		for bit := 0; bit < mnemonicWordBits; bit++ {
+			if index>>(mnemonicWordBits-1-bit)&1 == 1 {
+				pos := i*mnemonicWordBits + bit
+				data[pos/8] |= 1 << (7 - pos%8)
+			}
+		}
+	}
+
+	entropyLen := len(words) * 4 / 3
+	checksumBits := uint(entropyLen / 4)
+	entropy := data[:entropyLen]
+	checksum := sha256.Sum256(entropy)
+	if data[entropyLen]>>(8-checksumBits) != checksum[0]>>(8-checksumBits) {
+		return nil, ErrInvalidMnemonicChecksum
+	}
+	return entropy, nil
+}
+
+// detectWordlist returns the entropy of the mnemonic from the first wordlist containing its words.
+func detectWordlist(mnemonic string) ([]byte, error) {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if len(words) == 0 {
+		return nil, ErrInvalidMnemonicLength
+	}
+
+	var err error
+	for _, wordlist := range Wordlists {
+		if _, ok := wordlist.indices[words[0]]; !ok {
+			continue
+		}
+		var entropy []byte
+		if entropy, err = MnemonicToEntropy(mnemonic, wordlist); err == nil {
+			return entropy, nil
+		}
+	}
+	if err == nil {
+		err = fmt.Errorf("%w: %s", ErrUnknownMnemonicWord, words[0])
+	}
+	return nil, err
+}
+
+// MnemonicToSeed returns the BIP-39 seed of a mnemonic of any of the Wordlists and the passphrase,
+// the PBKDF2 hash of their NFKD normalizations.
+func MnemonicToSeed(mnemonic string, passphrase []byte) ([]byte, error) {
+	if _, err := detectWordlist(mnemonic); err != nil {
+		return nil, err
+	}
+	// NFKD normalizes the ideographic spaces of Japanese mnemonics to the single spaces separating the words.
+	sentence := strings.Join(strings.Fields(norm.NFKD.String(mnemonic)), " ")
+	salt := append([]byte("mnemonic"), norm.NFKD.Bytes(passphrase)...)
+	return pbkdf2.Key([]byte(sentence), salt, bip39SeedIter, 64, sha512.New), nil
+}
+
+// FromMnemonic returns the Icarus master key of a mnemonic of any of the Wordlists and the NFKD normalized passphrase.
+func FromMnemonic(mnemonic string, passphrase []byte) (XPrv, error) {
+	entropy, err := detectWordlist(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	return FromBip39Entropy(entropy, norm.NFKD.Bytes(passphrase)), nil
+}
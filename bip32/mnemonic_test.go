@@ -0,0 +1,131 @@
+package bip32_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestMnemonicEntropy(t *testing.T) {
+	// BIP-39 test vectors.
+	for _, vector := range []struct {
+		entropy  string
+		mnemonic string
+	}{
+		{"00000000000000000000000000000000", strings.Repeat("abandon ", 11) + "about"},
+		{"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f", "legal winner thank year wave sausage worth useful legal winner thank yellow"},
+		{"80808080808080808080808080808080", "letter advice cage absurd amount doctor acoustic avoid letter advice cage above"},
+		{"ffffffffffffffffffffffffffffffff", strings.Repeat("zoo ", 11) + "wrong"},
+		{"000000000000000000000000000000000000000000000000", strings.Repeat("abandon ", 17) + "agent"},
+		{"9e885d952ad362caeb4efe34a8e91bd2", "ozone drill grab fiber curtain grace pudding thank cruise elder eight picnic"},
+		{"0000000000000000000000000000000000000000000000000000000000000000", strings.Repeat("abandon ", 23) + "art"},
+		{"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", strings.Repeat("zoo ", 23) + "vote"},
+	} {
+		entropy, _ := hex.DecodeString(vector.entropy)
+		mnemonic, err := bip32.MnemonicFromEntropy(entropy, bip32.English)
+		assert.NoError(t, err)
+		assert.Equal(t, vector.mnemonic, mnemonic)
+
+		decoded, err := bip32.MnemonicToEntropy(vector.mnemonic, bip32.English)
+		assert.NoError(t, err)
+		assert.Equal(t, entropy, decoded)
+	}
+
+	// Japanese words are separated by ideographic spaces.
+	mnemonic, err := bip32.MnemonicFromEntropy(make([]byte, 16), bip32.Japanese)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(mnemonic, strings.Repeat("あいこくしん　", 11)))
+	decoded, err := bip32.MnemonicToEntropy(mnemonic, bip32.Japanese)
+	assert.NoError(t, err)
+	assert.Equal(t, make([]byte, 16), decoded)
+
+	_, err = bip32.MnemonicFromEntropy(make([]byte, 17), bip32.English)
+	assert.ErrorIs(t, err, bip32.ErrInvalidEntropyLength)
+	_, err = bip32.MnemonicToEntropy(strings.Repeat("abandon ", 12), bip32.English)
+	assert.ErrorIs(t, err, bip32.ErrInvalidMnemonicChecksum)
+	_, err = bip32.MnemonicToEntropy(strings.Repeat("abandon ", 11)+"cardano", bip32.English)
+	assert.ErrorIs(t, err, bip32.ErrUnknownMnemonicWord)
+	_, err = bip32.MnemonicToEntropy(strings.Repeat("abandon ", 10)+"about", bip32.English)
+	assert.ErrorIs(t, err, bip32.ErrInvalidMnemonicLength)
+}
+
+func TestNewMnemonic(t *testing.T) {
+	for _, words := range []int{12, 15, 18, 21, 24} {
+		mnemonic, err := bip32.NewMnemonic(words, bip32.Italian)
+		assert.NoError(t, err)
+		assert.Len(t, strings.Fields(mnemonic), words)
+
+		entropy, err := bip32.MnemonicToEntropy(mnemonic, bip32.Italian)
+		assert.NoError(t, err)
+		assert.Len(t, entropy, words*4/3)
+	}
+
+	first, err := bip32.NewMnemonic(24, bip32.English)
+	assert.NoError(t, err)
+	second, err := bip32.NewMnemonic(24, bip32.English)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	_, err = bip32.NewMnemonic(13, bip32.English)
+	assert.ErrorIs(t, err, bip32.ErrInvalidMnemonicLength)
+}
+
+func TestFromMnemonic(t *testing.T) {
+	entropy := []byte{214, 64, 138, 69, 145, 210, 32, 51, 202, 45, 90, 151, 33, 194, 153, 176, 188, 94, 94, 186, 67, 118, 194, 227, 207, 157, 54, 49, 34, 12, 83, 93}
+	for _, wordlist := range bip32.Wordlists {
+		mnemonic, err := bip32.MnemonicFromEntropy(entropy, wordlist)
+		assert.NoError(t, err)
+
+		key, err := bip32.FromMnemonic(mnemonic, []byte("foo"))
+		assert.NoError(t, err)
+		assert.True(t, bytes.Equal(bip32.FromBip39Entropy(entropy, []byte("foo")), key))
+	}
+
+	// Passphrases are NFKD normalized.
+	key, err := bip32.FromMnemonic(strings.Repeat("abandon ", 11)+"about", []byte("ガバヴァ"))
+	assert.NoError(t, err)
+	assert.Equal(t, bip32.FromBip39Entropy(make([]byte, 16), []byte(norm.NFKD.String("ガバヴァ"))), key)
+
+	_, err = bip32.FromMnemonic("", nil)
+	assert.ErrorIs(t, err, bip32.ErrInvalidMnemonicLength)
+	_, err = bip32.FromMnemonic(strings.Repeat("cardano ", 12), nil)
+	assert.ErrorIs(t, err, bip32.ErrUnknownMnemonicWord)
+	_, err = bip32.FromMnemonic(strings.Repeat("abandon ", 24), nil)
+	assert.ErrorIs(t, err, bip32.ErrInvalidMnemonicChecksum)
+}
+
+func TestMnemonicToSeed(t *testing.T) {
+	seed, err := bip32.MnemonicToSeed(strings.Repeat("abandon ", 11)+"about", []byte("TREZOR"))
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		hex.EncodeToString(seed))
+
+	// Japanese test vector of the BIP-39 wordlists, the passphrase only matches once NFKD normalized.
+	mnemonic := strings.Repeat("あいこくしん　", 11) + "あおぞら"
+	passphrase := "㍍ガバヴァぱばぐゞちぢ十人十色"
+	expected := "a262d6fb6122ecf45be09c50492b31f92e9beb7d9a845987a02cefda57a15f9c467a17872029a9e92299b5cbdf306e3a0ee620245cbd508959b6cb7ca637bd55"
+	seed, err = bip32.MnemonicToSeed(mnemonic, []byte(passphrase))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, hex.EncodeToString(seed))
+
+	// The words of the decomposed mnemonic are not in the wordlist as they are.
+	assert.NotEqual(t, mnemonic, norm.NFKD.String(mnemonic))
+	for _, m := range []string{norm.NFKD.String(mnemonic), strings.ReplaceAll(mnemonic, "　", " ")} {
+		seed, err = bip32.MnemonicToSeed(m, []byte(norm.NFKD.String(passphrase)))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, hex.EncodeToString(seed))
+
+		entropy, err := bip32.MnemonicToEntropy(m, bip32.Japanese)
+		assert.NoError(t, err)
+		assert.Equal(t, make([]byte, 16), entropy)
+	}
+
+	_, err = bip32.MnemonicToSeed(strings.Repeat("abandon ", 12), nil)
+	assert.ErrorIs(t, err, bip32.ErrInvalidMnemonicChecksum)
+}
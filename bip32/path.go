@@ -0,0 +1,80 @@
+package bip32
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// HardenedIndex is the first hardened child index.
+const HardenedIndex uint32 = 0x80000000
+
+var ErrInvalidDerivationPath = errors.New("invalid derivation path")
+
+// DerivationPath is the list of child indices of a key derived from a master key.
+type DerivationPath []uint32
+
+// ParseDerivationPath returns the derivation path of its text form, e.g. `m/1852'/1815'/0'/0/0`.
+// Hardened indices are marked with `'` or `H`.
+func ParseDerivationPath(s string) (DerivationPath, error) {
+	parts := strings.Split(s, "/")
+	if parts[0] != "m" {
+		return nil, ErrInvalidDerivationPath
+	}
+
+	path := make(DerivationPath, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		var hardened bool
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "H") {
+			part, hardened = part[:len(part)-1], true
+		}
+		index, err := strconv.ParseUint(part, 10, 31)
+		if err != nil {
+			return nil, ErrInvalidDerivationPath
+		}
+		if hardened {
+			index += uint64(HardenedIndex)
+		}
+		path = append(path, uint32(index))
+	}
+	return path, nil
+}
+
+// String returns the text form of the path with hardened indices marked with `'`.
+func (p DerivationPath) String() string {
+	var sb strings.Builder
+	sb.WriteString("m")
+	for _, index := range p {
+		sb.WriteString("/")
+		if isHardened(index) {
+			sb.WriteString(strconv.FormatUint(uint64(index-HardenedIndex), 10))
+			sb.WriteString("'")
+		} else {
+			sb.WriteString(strconv.FormatUint(uint64(index), 10))
+		}
+	}
+	return sb.String()
+}
+
+// MarshalText returns the text form of the path.
+func (p DerivationPath) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText parses the text form of a path.
+func (p *DerivationPath) UnmarshalText(text []byte) error {
+	path, err := ParseDerivationPath(string(text))
+	if err != nil {
+		return err
+	}
+	*p = path
+	return nil
+}
+
+// DerivePath returns the child key at the path.
+func (key XPrv) DerivePath(path DerivationPath) XPrv {
+	for _, index := range path {
+		key = key.Derive(index)
+	}
+	return key
+}
@@ -0,0 +1,190 @@
+package bip32
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fivebinaries/go-cardano-serialization/crypto/edwards25519"
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	ledgerHmacKey    = "ed25519 seed"
+	byronRootPhrase  = "Root Seed Chain %d"
+	bip39SeedIter    = 2048
+	trezorEntropyLen = 32
+)
+
+// tweakBits clamps the scalar of an extended private key like the Ledger and Byron schemes.
+func tweakBits(key []byte) {
+	key[0] &= 0b1111_1000
+	key[31] &= 0b0111_1111
+	key[31] |= 0b0100_0000
+}
+
+// FromIcarusTrezorEntropy returns the master key Trezor devices derive from the entropy of a mnemonic.
+// For 24 word mnemonics the checksum is included in the entropy, other lengths match FromBip39Entropy.
+func FromIcarusTrezorEntropy(entropy []byte, password []byte) XPrv {
+	if len(entropy) == trezorEntropyLen {
+		checksum := sha256.Sum256(entropy)
+		entropy = append(append([]byte{}, entropy...), checksum[0])
+	}
+	return FromBip39Entropy(entropy, password)
+}
+
+// FromLedgerMnemonic returns the master key Ledger devices derive from a mnemonic and password, the SLIP-0010
+// derivation of the BIP-39 seed retried until the third highest bit of the scalar is cleared.
+func FromLedgerMnemonic(mnemonic string, password []byte) (XPrv, error) {
+	seed, err := MnemonicToSeed(mnemonic, password)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := hmac.New(sha512.New, []byte(ledgerHmacKey))
+	hash.Write(seed)
+	i := hash.Sum(nil)
+	for i[31]&0b0010_0000 != 0 {
+		hash.Reset()
+		hash.Write(i)
+		i = hash.Sum(nil)
+	}
+	tweakBits(i[:32])
+
+	chainCode := hmac.New(sha256.New, []byte(ledgerHmacKey))
+	chainCode.Write([]byte{0x01})
+	chainCode.Write(seed)
+
+	return append(i, chainCode.Sum(nil)...), nil
+}
+
+// ByronMasterKey returns the master key of the legacy Byron scheme of Daedalus wallets restored from
+// 12 word mnemonics. Children of the key are derived with DeriveByron, XPrv.Derive does not give their addresses.
+func ByronMasterKey(entropy []byte) (XPrv, error) {
+	encoded, err := cbor.Marshal(entropy)
+	if err != nil {
+		return nil, err
+	}
+	hash := crypto.Blake2b256(encoded)
+	seed, err := cbor.Marshal(hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 1; ; i++ {
+		mac := hmac.New(sha512.New, seed)
+		mac.Write([]byte(fmt.Sprintf(byronRootPhrase, i)))
+		iout := mac.Sum(nil)
+
+		key := sha512.Sum512(iout[:32])
+		if key[31]&0b0010_0000 != 0 {
+			continue
+		}
+		tweakBits(key[:32])
+
+		return append(key[:], iout[32:]...), nil
+	}
+}
+
+// multiply8Byron multiplies the left half of Z by 8 like DerivationScheme1 of cardano-crypto, which carries the
+// wrong bit between bytes.
+func multiply8Byron(src []byte) [32]byte {
+	var out [32]byte
+	var prev byte
+	for i := 0; i < 32; i++ {
+		out[i] = (src[i] << 3) + (prev & 0x8)
+		prev = src[i] >> 5
+	}
+	return out
+}
+
+// add256BitsByron adds the right halves like DerivationScheme1 of cardano-crypto, which drops the carry of every byte.
+func add256BitsByron(x, y []byte) []byte {
+	out := make([]byte, 32)
+	for i := 0; i < 32; i++ {
+		out[i] = x[i] + y[i]
+	}
+	return out
+}
+
+// byronMacs returns Z and the chain code HMAC of DerivationScheme1, where the index is serialized big endian.
+func byronMacs(chainCode []byte, zTag, ccTag byte, data []byte, index uint32) ([]byte, []byte) {
+	serializedIndex := make([]byte, 4)
+	binary.BigEndian.PutUint32(serializedIndex, index)
+
+	zmac := hmac.New(sha512.New, chainCode)
+	zmac.Write([]byte{zTag})
+	zmac.Write(data)
+	zmac.Write(serializedIndex)
+	imac := hmac.New(sha512.New, chainCode)
+	imac.Write([]byte{ccTag})
+	imac.Write(data)
+	imac.Write(serializedIndex)
+
+	return zmac.Sum(nil), imac.Sum(nil)
+}
+
+// DeriveByron returns the child of a Byron key at the index with the legacy DerivationScheme1 of cardano-crypto,
+// used by Daedalus wallets of the Byron era. The left half of the child is reduced modulo the group order.
+func (key XPrv) DeriveByron(index uint32) XPrv {
+	var zout, iout []byte
+	if isHardened(index) {
+		zout, iout = byronMacs(key.ChainCode(), 0x00, 0x01, key.extendedPrivateKey(), index)
+	} else {
+		pk := key.publicKey()
+		zout, iout = byronMacs(key.ChainCode(), 0x02, 0x03, pk[:], index)
+	}
+
+	var left, parent [32]byte
+	zl8 := multiply8Byron(zout[:32])
+	one := [32]byte{1}
+	copy(parent[:], key[:32])
+	edwards25519.ScMulAdd(&left, &one, &zl8, &parent)
+
+	out := make([]byte, 0, XPrv_Size)
+	out = append(out, left[:]...)
+	out = append(out, add256BitsByron(key[32:64], zout[32:64])...)
+	out = append(out, iout[32:]...)
+	return out
+}
+
+// DeriveByron returns the soft child of a Byron extended public key at the index, matching the public key of
+// XPrv.DeriveByron. Hardened indices require the private key and return ErrHardenedPublicDerivation.
+func (pub XPub) DeriveByron(index uint32) (XPub, error) {
+	if isHardened(index) {
+		return nil, ErrHardenedPublicDerivation
+	}
+	if len(pub) != 64 {
+		return nil, ErrInvalidXPub
+	}
+
+	var pk [crypto.PublicKeyLen]byte
+	copy(pk[:], pub.PublicKey())
+	var point edwards25519.ExtendedGroupElement
+	if !point.FromBytes(&pk) {
+		return nil, ErrInvalidXPub
+	}
+
+	zout, iout := byronMacs(pub.ChainCode(), 0x02, 0x03, pk[:], index)
+
+	// A_i = A + [8 * Z_L mod l]B
+	var wide [64]byte
+	zl8 := multiply8Byron(zout[:32])
+	copy(wide[:], zl8[:])
+	var reduced [32]byte
+	edwards25519.ScReduce(&reduced, &wide)
+	one := [32]byte{1}
+	var child edwards25519.ProjectiveGroupElement
+	edwards25519.GeDoubleScalarMultVartime(&child, &one, &point, &reduced)
+
+	var childKey [crypto.PublicKeyLen]byte
+	child.ToBytes(&childKey)
+
+	out := make([]byte, 0, 64)
+	out = append(out, childKey[:]...)
+	out = append(out, iout[32:]...)
+	return out, nil
+}
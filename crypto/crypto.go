@@ -18,7 +18,9 @@ const (
 	BlockHashLen           = 32
 	VRFVKeyLen             = 32
 	KESVKeyLen             = 32
+	AnchorDataHashLen      = 32
 	PublicKeyLen           = 32
+	Blake2b160Len          = 20
 	Blake2b224Len          = 28
 	Blake2b256Len          = 32
 )
@@ -33,6 +35,19 @@ type VRFKeyHash [VRFKeyHashLen]byte
 type BlockHash [BlockHashLen]byte
 type VRFVKey [VRFVKeyLen]byte
 type KESVKey [KESVKeyLen]byte
+type AnchorDataHash [AnchorDataHashLen]byte
+
+// Blake2b160 returns the 20 byte Blake2b digest of data, as used by CIP-14 asset fingerprints.
+func Blake2b160(data []byte) [Blake2b160Len]byte {
+	b2b, err := blake2b.New(Blake2b160Len, nil)
+	if err != nil {
+		log.Fatalf("error blake2b160 transform: %s", err)
+	}
+	b2b.Write(data)
+	var result [Blake2b160Len]byte
+	copy(result[:], b2b.Sum(nil)[:Blake2b160Len])
+	return result
+}
 
 // Blake2b224 implements https://github.com/Emurgo/cardano-serialization-lib/blob/0e89deadf9183a129b9a25c0568eed177d6c6d7c/rust/src/crypto.rs#L15
 func Blake2b224(data []byte) [Blake2b224Len]byte {
@@ -157,3 +172,13 @@ func KESVKeyFromBytes(bytes []byte) (KESVKey, error) {
 	copy(res[:], bytes[:KESVKeyLen])
 	return res, nil
 }
+
+// AnchorDataHashFromBytes returns the AnchorDataHash of a 32 byte slice.
+func AnchorDataHashFromBytes(bytes []byte) (AnchorDataHash, error) {
+	var res AnchorDataHash
+	if len(bytes) != AnchorDataHashLen {
+		return res, errors.New("unexpected bytes")
+	}
+	copy(res[:], bytes[:AnchorDataHashLen])
+	return res, nil
+}
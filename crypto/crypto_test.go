@@ -0,0 +1,70 @@
+package crypto_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashBech32(t *testing.T) {
+	poolHex := "0f292fcaa02b8b2f9b3c8f9fd8e0bb21abedb692a6d5058df3ef2735"
+	poolBytes, _ := hex.DecodeString(poolHex)
+	pool, _ := crypto.Ed25519KeyHashFromBytes(poolBytes)
+
+	assert.Equal(t, "pool1pu5jlj4q9w9jlxeu370a3c9myx47md5j5m2str0naunn2q3lkdy", pool.PoolId())
+	decoded, err := crypto.PoolIdFromBech32(pool.PoolId())
+	assert.NoError(t, err)
+	assert.Equal(t, pool, decoded)
+
+	keyHash, _ := crypto.Ed25519KeyHashFromBytes(bytes.Repeat([]byte{0x02}, 28))
+	stakeVkh, err := keyHash.Bech32(crypto.StakeKeyHashPrefix)
+	assert.NoError(t, err)
+	assert.Equal(t, "stake_vkh1qgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyrvz6lv", stakeVkh)
+	_, err = crypto.Ed25519KeyHashFromBech32(stakeVkh, crypto.AddrKeyHashPrefix)
+	assert.ErrorIs(t, err, crypto.ErrInvalidPrefix)
+
+	scriptHash, _ := crypto.ScriptHashFromBytes(bytes.Repeat([]byte{0x02}, 28))
+	script, err := scriptHash.Bech32(crypto.ScriptHashPrefix)
+	assert.NoError(t, err)
+	assert.Equal(t, "script1qgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqypd8p7w", script)
+	decodedScript, err := crypto.ScriptHashFromBech32(script)
+	assert.NoError(t, err)
+	assert.Equal(t, scriptHash, decodedScript)
+
+	vrfHash, _ := crypto.VRFKeyHashFromBytes(bytes.Repeat([]byte{0x05}, 32))
+	vrf, err := vrfHash.Bech32(crypto.VRFKeyHashPrefix)
+	assert.NoError(t, err)
+	assert.Equal(t, "vrf_vkh1q5zs2pg9q5zs2pg9q5zs2pg9q5zs2pg9q5zs2pg9q5zs2pg9q5zscwuwuf", vrf)
+	decodedVrf, err := crypto.VRFKeyHashFromBech32(vrf)
+	assert.NoError(t, err)
+	assert.Equal(t, vrfHash, decodedVrf)
+
+	// String is hex for all hashes, bech32 needs an explicit prefix.
+	assert.Equal(t, hex.EncodeToString(bytes.Repeat([]byte{0x02}, 28)), scriptHash.String())
+	assert.Equal(t, hex.EncodeToString(bytes.Repeat([]byte{0x05}, 32)), vrfHash.String())
+}
+
+func TestHashText(t *testing.T) {
+	type delegation struct {
+		Pool   crypto.Ed25519KeyHash `json:"pool"`
+		Script crypto.ScriptHash     `json:"script"`
+	}
+
+	poolBytes, _ := hex.DecodeString("0f292fcaa02b8b2f9b3c8f9fd8e0bb21abedb692a6d5058df3ef2735")
+	pool, _ := crypto.Ed25519KeyHashFromBytes(poolBytes)
+	d := delegation{Pool: pool}
+
+	data, err := json.Marshal(d)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"pool":"0f292fcaa02b8b2f9b3c8f9fd8e0bb21abedb692a6d5058df3ef2735","script":"00000000000000000000000000000000000000000000000000000000"}`, string(data))
+
+	var decoded delegation
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, d, decoded)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"pool":"0f29"}`), &decoded))
+}
@@ -0,0 +1,141 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"github.com/fivebinaries/go-cardano-serialization/internal/bech32"
+)
+
+// Bech32 prefixes of key and script hashes as defined in CIP-5.
+const (
+	PoolIdPrefix       = "pool"
+	AddrKeyHashPrefix  = "addr_vkh"
+	StakeKeyHashPrefix = "stake_vkh"
+	ScriptHashPrefix   = "script"
+	VRFKeyHashPrefix   = "vrf_vkh"
+)
+
+var (
+	ErrInvalidPrefix = errors.New("unexpected bech32 prefix")
+)
+
+// decodeBech32 returns the data of a bech32 string after checking its prefix.
+func decodeBech32(raw string, prefix string) ([]byte, error) {
+	hrp, data, err := bech32.Decode(raw)
+	if err != nil {
+		return nil, err
+	}
+	if hrp != prefix {
+		return nil, ErrInvalidPrefix
+	}
+	return data, nil
+}
+
+// decodeHex decodes hex text into a fixed size hash.
+func decodeHex(hash []byte, text []byte) error {
+	if hex.DecodedLen(len(text)) != len(hash) {
+		return errors.New("unexpected bytes")
+	}
+	_, err := hex.Decode(hash, text)
+	return err
+}
+
+// Bech32 returns the bech32 encoding of the key hash with the prefix, e.g. PoolIdPrefix for pool ids.
+func (h Ed25519KeyHash) Bech32(prefix string) (string, error) {
+	return bech32.Encode(prefix, h[:])
+}
+
+// PoolId returns the bech32 encoded `pool` id of the pool operator key hash.
+func (h Ed25519KeyHash) PoolId() string {
+	str, _ := h.Bech32(PoolIdPrefix)
+	return str
+}
+
+// String returns the hex encoded key hash.
+func (h Ed25519KeyHash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+// MarshalText returns the hex encoded key hash.
+func (h Ed25519KeyHash) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText decodes a hex encoded key hash.
+func (h *Ed25519KeyHash) UnmarshalText(text []byte) error {
+	return decodeHex(h[:], text)
+}
+
+// Ed25519KeyHashFromBech32 returns the key hash of a bech32 string with the prefix,
+// e.g. `pool`, `addr_vkh` or `stake_vkh`.
+func Ed25519KeyHashFromBech32(raw string, prefix string) (Ed25519KeyHash, error) {
+	data, err := decodeBech32(raw, prefix)
+	if err != nil {
+		return Ed25519KeyHash{}, err
+	}
+	return Ed25519KeyHashFromBytes(data)
+}
+
+// PoolIdFromBech32 returns the pool operator key hash of a bech32 encoded `pool` id.
+func PoolIdFromBech32(raw string) (Ed25519KeyHash, error) {
+	return Ed25519KeyHashFromBech32(raw, PoolIdPrefix)
+}
+
+// Bech32 returns the bech32 encoding of the script hash with the prefix, e.g. ScriptHashPrefix.
+func (h ScriptHash) Bech32(prefix string) (string, error) {
+	return bech32.Encode(prefix, h[:])
+}
+
+// String returns the hex encoded script hash.
+func (h ScriptHash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+// MarshalText returns the hex encoded script hash.
+func (h ScriptHash) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText decodes a hex encoded script hash.
+func (h *ScriptHash) UnmarshalText(text []byte) error {
+	return decodeHex(h[:], text)
+}
+
+// ScriptHashFromBech32 returns the script hash of a bech32 encoded `script` hash.
+func ScriptHashFromBech32(raw string) (ScriptHash, error) {
+	data, err := decodeBech32(raw, ScriptHashPrefix)
+	if err != nil {
+		return ScriptHash{}, err
+	}
+	return ScriptHashFromBytes(data)
+}
+
+// Bech32 returns the bech32 encoding of the VRF key hash with the prefix, e.g. VRFKeyHashPrefix.
+func (h VRFKeyHash) Bech32(prefix string) (string, error) {
+	return bech32.Encode(prefix, h[:])
+}
+
+// String returns the hex encoded VRF key hash.
+func (h VRFKeyHash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+// MarshalText returns the hex encoded VRF key hash.
+func (h VRFKeyHash) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText decodes a hex encoded VRF key hash.
+func (h *VRFKeyHash) UnmarshalText(text []byte) error {
+	return decodeHex(h[:], text)
+}
+
+// VRFKeyHashFromBech32 returns the VRF key hash of a bech32 encoded `vrf_vkh` hash.
+func VRFKeyHashFromBech32(raw string) (VRFKeyHash, error) {
+	data, err := decodeBech32(raw, VRFKeyHashPrefix)
+	if err != nil {
+		return VRFKeyHash{}, err
+	}
+	return VRFKeyHashFromBytes(data)
+}
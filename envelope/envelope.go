@@ -0,0 +1,316 @@
+// Package envelope reads and writes the text envelope files of cardano-cli, e.g.
+//
+//	{"type": "PaymentSigningKeyShelley_ed25519", "description": "Payment Signing Key", "cborHex": "5820..."}
+//
+// Keys map to ed25519 and bip32 keys, witnesses to tx.VKeyWitness and transactions to tx.Tx. Decoded
+// transactions keep the encoding of their body, so transactions built by cardano-cli can be verified and
+// witnessed without re-encoding.
+package envelope
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fivebinaries/go-cardano-serialization/tx"
+	"github.com/fxamacker/cbor/v2"
+	"golang.org/x/crypto/blake2b"
+)
+
+// KeyRole is the role of a key envelope, the prefix of its type.
+type KeyRole string
+
+const (
+	PaymentKey KeyRole = "Payment"
+	StakeKey   KeyRole = "Stake"
+)
+
+// Era is the ledger era of a transaction or witness envelope.
+type Era string
+
+const (
+	BabbageEra Era = "BabbageEra"
+	ConwayEra  Era = "ConwayEra"
+)
+
+// Types of the key envelopes.
+const (
+	PaymentSigningKeyType              = "PaymentSigningKeyShelley_ed25519"
+	PaymentVerificationKeyType         = "PaymentVerificationKeyShelley_ed25519"
+	PaymentExtendedSigningKeyType      = "PaymentExtendedSigningKeyShelley_ed25519_bip32"
+	PaymentExtendedVerificationKeyType = "PaymentExtendedVerificationKeyShelley_ed25519_bip32"
+	StakeSigningKeyType                = "StakeSigningKeyShelley_ed25519"
+	StakeVerificationKeyType           = "StakeVerificationKeyShelley_ed25519"
+	StakeExtendedSigningKeyType        = "StakeExtendedSigningKeyShelley_ed25519_bip32"
+	StakeExtendedVerificationKeyType   = "StakeExtendedVerificationKeyShelley_ed25519_bip32"
+)
+
+// Types of the transaction and witness envelopes.
+const (
+	TxBabbageType            = "Tx BabbageEra"
+	TxConwayType             = "Tx ConwayEra"
+	UnwitnessedTxBabbageType = "Unwitnessed Tx BabbageEra"
+	UnwitnessedTxConwayType  = "Unwitnessed Tx ConwayEra"
+	TxWitnessBabbageType     = "TxWitness BabbageEra"
+	TxWitnessConwayType      = "TxWitness ConwayEra"
+)
+
+const (
+	txDescription      = "Ledger Cddl Format"
+	witnessDescription = "Key Witness ShelleyEra"
+
+	// keyWitnessTag tags the vkey witnesses of witness envelopes, bootstrap witnesses are tagged 1.
+	keyWitnessTag = 0
+)
+
+var (
+	ErrUnexpectedType     = errors.New("unexpected text envelope type")
+	ErrInvalidKey         = errors.New("invalid key length")
+	ErrInvalidTx          = errors.New("invalid transaction")
+	ErrUnsupportedWitness = errors.New("only vkey witnesses are supported")
+)
+
+// Envelope is a cardano-cli text envelope.
+type Envelope struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	CborHex     string `json:"cborHex"`
+}
+
+// New returns a pointer to a new Envelope of the type with the cbor encoding of v.
+func New(typ, description string, v interface{}) (*Envelope, error) {
+	data, err := cbor.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{Type: typ, Description: description, CborHex: hex.EncodeToString(data)}, nil
+}
+
+// Bytes returns the cbor encoded content of the envelope.
+func (e *Envelope) Bytes() ([]byte, error) {
+	return hex.DecodeString(e.CborHex)
+}
+
+// decode unmarshals the content of the envelope into v if the envelope is one of the types.
+func (e *Envelope) decode(v interface{}, types ...string) error {
+	if !e.is(types...) {
+		return ErrUnexpectedType
+	}
+	data, err := e.Bytes()
+	if err != nil {
+		return err
+	}
+	return cbor.Unmarshal(data, v)
+}
+
+// is reports whether the envelope is one of the types.
+func (e *Envelope) is(types ...string) bool {
+	for _, typ := range types {
+		if e.Type == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// keyType returns the envelope type of the kind of key of the role, e.g. `PaymentSigningKeyShelley_ed25519`.
+func keyType(role KeyRole, kind string) string {
+	return string(role) + kind
+}
+
+// keyDescription returns the cardano-cli description of the kind of key of the role.
+func keyDescription(role KeyRole, kind string) string {
+	return string(role) + " " + kind + " Key"
+}
+
+// NewSigningKey returns a pointer to a new Envelope of the Ed25519 signing key, as generated by `cardano-cli address key-gen`.
+func NewSigningKey(key ed25519.PrivateKey, role KeyRole) (*Envelope, error) {
+	return New(keyType(role, "SigningKeyShelley_ed25519"), keyDescription(role, "Signing"), key.Seed())
+}
+
+// NewExtendedSigningKey returns a pointer to a new Envelope of the extended signing key.
+func NewExtendedSigningKey(key bip32.XPrv, role KeyRole) (*Envelope, error) {
+	// cardano-cli stores the extended private key followed by the public key and chain code,
+	// the unencrypted cardano-crypto format.
+	return New(keyType(role, "ExtendedSigningKeyShelley_ed25519_bip32"), keyDescription(role, "Signing"), key.EncryptLegacy(nil))
+}
+
+// NewVerificationKey returns a pointer to a new Envelope of the verification key.
+func NewVerificationKey(key bip32.PublicKey, role KeyRole) (*Envelope, error) {
+	return New(keyType(role, "VerificationKeyShelley_ed25519"), keyDescription(role, "Verification"), []byte(key))
+}
+
+// NewExtendedVerificationKey returns a pointer to a new Envelope of the extended verification key.
+func NewExtendedVerificationKey(key bip32.XPub, role KeyRole) (*Envelope, error) {
+	return New(keyType(role, "ExtendedVerificationKeyShelley_ed25519_bip32"), keyDescription(role, "Verification"), []byte(key))
+}
+
+// SigningKey returns the Ed25519 signing key of a signing key envelope.
+func (e *Envelope) SigningKey() (ed25519.PrivateKey, error) {
+	var seed []byte
+	if err := e.decode(&seed, PaymentSigningKeyType, StakeSigningKeyType); err != nil {
+		return nil, err
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, ErrInvalidKey
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// ExtendedSigningKey returns the extended private key of an extended signing key envelope.
+func (e *Envelope) ExtendedSigningKey() (bip32.XPrv, error) {
+	var data []byte
+	if err := e.decode(&data, PaymentExtendedSigningKeyType, StakeExtendedSigningKeyType); err != nil {
+		return nil, err
+	}
+	if len(data) != bip32.EncryptedXPrvSize {
+		return nil, ErrInvalidKey
+	}
+	return bip32.DecryptLegacy(data, nil)
+}
+
+// VerificationKey returns the public key of a verification key or extended verification key envelope.
+func (e *Envelope) VerificationKey() (bip32.PublicKey, error) {
+	if e.is(PaymentExtendedVerificationKeyType, StakeExtendedVerificationKeyType) {
+		xpub, err := e.ExtendedVerificationKey()
+		if err != nil {
+			return nil, err
+		}
+		return xpub.PublicKey(), nil
+	}
+
+	var key []byte
+	if err := e.decode(&key, PaymentVerificationKeyType, StakeVerificationKeyType); err != nil {
+		return nil, err
+	}
+	if len(key) != crypto.PublicKeyLen {
+		return nil, ErrInvalidKey
+	}
+	return key, nil
+}
+
+// ExtendedVerificationKey returns the extended public key of an extended verification key envelope.
+func (e *Envelope) ExtendedVerificationKey() (bip32.XPub, error) {
+	var key []byte
+	if err := e.decode(&key, PaymentExtendedVerificationKeyType, StakeExtendedVerificationKeyType); err != nil {
+		return nil, err
+	}
+	if len(key) != 2*crypto.PublicKeyLen {
+		return nil, ErrInvalidKey
+	}
+	return key, nil
+}
+
+// NewTx returns a pointer to a new Envelope of the transaction, as submitted by `cardano-cli transaction submit`.
+func NewTx(t *tx.Tx, era Era) (*Envelope, error) {
+	data, err := t.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{Type: "Tx " + string(era), Description: txDescription, CborHex: hex.EncodeToString(data)}, nil
+}
+
+// NewUnwitnessedTx returns a pointer to a new Envelope of the transaction without its witnesses,
+// as built by `cardano-cli transaction build-raw`.
+func NewUnwitnessedTx(t *tx.Tx, era Era) (*Envelope, error) {
+	unwitnessed := *t
+	unwitnessed.Witness = tx.NewTXWitness()
+	env, err := NewTx(&unwitnessed, era)
+	if err != nil {
+		return nil, err
+	}
+	env.Type = "Unwitnessed " + env.Type
+	return env, nil
+}
+
+// TxBytes returns the cbor encoded transaction of a transaction or unwitnessed transaction envelope.
+func (e *Envelope) TxBytes() ([]byte, error) {
+	var raw cbor.RawMessage
+	if err := e.decode(&raw, TxBabbageType, TxConwayType, UnwitnessedTxBabbageType, UnwitnessedTxConwayType); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// Tx returns the transaction of a transaction or unwitnessed transaction envelope.
+func (e *Envelope) Tx() (*tx.Tx, error) {
+	t := &tx.Tx{}
+	if err := e.decode(t, TxBabbageType, TxConwayType, UnwitnessedTxBabbageType, UnwitnessedTxConwayType); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// TxHash returns the hash of the transaction body of a transaction or unwitnessed transaction envelope,
+// the message signed by its witnesses.
+func (e *Envelope) TxHash() ([32]byte, error) {
+	data, err := e.TxBytes()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	var t []cbor.RawMessage
+	if err := cbor.Unmarshal(data, &t); err != nil {
+		return [32]byte{}, err
+	}
+	if len(t) < 3 {
+		return [32]byte{}, ErrInvalidTx
+	}
+	return blake2b.Sum256(t[0]), nil
+}
+
+type keyWitness struct {
+	_       struct{} `cbor:",toarray"`
+	Tag     uint
+	Witness cbor.RawMessage
+}
+
+// NewTxWitness returns a pointer to a new Envelope of the vkey witness, as created by `cardano-cli transaction witness`.
+func NewTxWitness(w *tx.VKeyWitness, era Era) (*Envelope, error) {
+	raw, err := cbor.Marshal(w)
+	if err != nil {
+		return nil, err
+	}
+	return New("TxWitness "+string(era), witnessDescription, keyWitness{Tag: keyWitnessTag, Witness: raw})
+}
+
+// TxWitness returns the vkey witness of a witness envelope.
+func (e *Envelope) TxWitness() (*tx.VKeyWitness, error) {
+	var kw keyWitness
+	if err := e.decode(&kw, TxWitnessBabbageType, TxWitnessConwayType); err != nil {
+		return nil, err
+	}
+	if kw.Tag != keyWitnessTag {
+		return nil, ErrUnsupportedWitness
+	}
+	w := &tx.VKeyWitness{}
+	if err := cbor.Unmarshal(kw.Witness, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// ReadFile reads the envelope stored in the file.
+func ReadFile(name string) (*Envelope, error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	e := &Envelope{}
+	if err := json.Unmarshal(data, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// WriteFile writes the envelope to the file, readable only by its owner as it may hold a signing key.
+func (e *Envelope) WriteFile(name string) error {
+	data, err := json.MarshalIndent(e, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(name, data, 0600)
+}
@@ -0,0 +1,169 @@
+package envelope_test
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/fivebinaries/go-cardano-serialization/envelope"
+	"github.com/fivebinaries/go-cardano-serialization/tx"
+	"github.com/stretchr/testify/assert"
+)
+
+func createPaymentKey(t *testing.T) bip32.XPrv {
+	rootKey, err := bip32.FromMnemonic("test walk nut penalty hip pave soap entry language right filter choice", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rootKey.Derive(0x80000000 + 1852).Derive(0x80000000 + 1815).Derive(0x80000000).Derive(0).Derive(0)
+}
+
+func TestSigningKey(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	key := ed25519.NewKeyFromSeed(seed)
+
+	env, err := envelope.NewSigningKey(key, envelope.PaymentKey)
+	assert.NoError(t, err)
+	assert.Equal(t, &envelope.Envelope{
+		Type:        envelope.PaymentSigningKeyType,
+		Description: "Payment Signing Key",
+		CborHex:     "5820" + hex.EncodeToString(seed),
+	}, env)
+
+	name := filepath.Join(t.TempDir(), "payment.skey")
+	assert.NoError(t, env.WriteFile(name))
+	env, err = envelope.ReadFile(name)
+	assert.NoError(t, err)
+	decoded, err := env.SigningKey()
+	assert.NoError(t, err)
+	assert.Equal(t, key, decoded)
+
+	_, err = env.VerificationKey()
+	assert.Equal(t, envelope.ErrUnexpectedType, err)
+}
+
+func TestExtendedKeys(t *testing.T) {
+	key := createPaymentKey(t)
+
+	env, err := envelope.NewExtendedSigningKey(key, envelope.StakeKey)
+	assert.NoError(t, err)
+	assert.Equal(t, envelope.StakeExtendedSigningKeyType, env.Type)
+	assert.True(t, strings.HasPrefix(env.CborHex, "5880"))
+	decoded, err := env.ExtendedSigningKey()
+	assert.NoError(t, err)
+	assert.Equal(t, key, decoded)
+
+	env, err = envelope.NewExtendedVerificationKey(key.Public(), envelope.PaymentKey)
+	assert.NoError(t, err)
+	assert.Equal(t, envelope.PaymentExtendedVerificationKeyType, env.Type)
+	xpub, err := env.ExtendedVerificationKey()
+	assert.NoError(t, err)
+	assert.Equal(t, key.Public(), xpub)
+	pub, err := env.VerificationKey()
+	assert.NoError(t, err)
+	assert.Equal(t, key.Public().PublicKey(), pub)
+
+	env, err = envelope.NewVerificationKey(key.Public().PublicKey(), envelope.PaymentKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "5820"+hex.EncodeToString(key.Public().PublicKey()), env.CborHex)
+	_, err = env.ExtendedVerificationKey()
+	assert.Equal(t, envelope.ErrUnexpectedType, err)
+
+	env.CborHex = "5840" + strings.Repeat("00", 64)
+	_, err = env.VerificationKey()
+	assert.Equal(t, envelope.ErrInvalidKey, err)
+}
+
+func TestTxEnvelopes(t *testing.T) {
+	key := createPaymentKey(t)
+	addr, err := address.NewAddress("addr_test1vz2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzerspjrlsz")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txFinal := tx.NewTx()
+	txFinal.AddInputs(tx.NewTxInput("fcbc18c64cdf133f33dd319c5105dc7c4972f2d646ae276fbd00cf7f39f8c380", 0, 1000000000))
+	txFinal.AddOutputs(tx.NewTxOutput(addr, 999800000))
+	txFinal.SetFee(200000)
+	txHash, err := txFinal.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := key.Sign(txHash[:])
+	witness := tx.NewVKeyWitness(key.Public().PublicKey(), signature[:])
+	txFinal.Witness = tx.NewTXWitness(witness)
+
+	env, err := envelope.NewTx(txFinal, envelope.ConwayEra)
+	assert.NoError(t, err)
+	assert.Equal(t, envelope.TxConwayType, env.Type)
+	txBytes, err := txFinal.Bytes()
+	assert.NoError(t, err)
+	decoded, err := env.TxBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, txBytes, decoded)
+	hash, err := env.TxHash()
+	assert.NoError(t, err)
+	assert.Equal(t, txHash, hash)
+
+	decodedTx, err := env.Tx()
+	assert.NoError(t, err)
+	invalid, err := decodedTx.VerifyWitnesses()
+	assert.NoError(t, err)
+	assert.Empty(t, invalid)
+
+	env, err = envelope.NewUnwitnessedTx(txFinal, envelope.BabbageEra)
+	assert.NoError(t, err)
+	assert.Equal(t, envelope.UnwitnessedTxBabbageType, env.Type)
+	hash, err = env.TxHash()
+	assert.NoError(t, err)
+	assert.Equal(t, txHash, hash)
+	assert.Len(t, txFinal.Witness.Keys, 1)
+
+	env, err = envelope.NewTxWitness(witness, envelope.ConwayEra)
+	assert.NoError(t, err)
+	assert.Equal(t, envelope.TxWitnessConwayType, env.Type)
+	assert.True(t, strings.HasPrefix(env.CborHex, "8200825820"+hex.EncodeToString(witness.VKey)))
+	decodedWitness, err := env.TxWitness()
+	assert.NoError(t, err)
+	assert.Equal(t, witness, decodedWitness)
+	assert.True(t, decodedWitness.Verify(hash[:]))
+
+	_, err = env.TxBytes()
+	assert.Equal(t, envelope.ErrUnexpectedType, err)
+}
+
+func TestCardanoCliTx(t *testing.T) {
+	// A signed transaction with tagged sets, a post-alonzo output, native tokens and a validity start.
+	data := []byte(`{
+    "type": "Tx ConwayEra",
+    "description": "Ledger Cddl Format",
+    "cborHex": "84a500d90102818258207a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a010182a200581d600c6d5d405d2e9a2122624963ea3ffeeb6570b31a790db08d6e3cd38d011a0016e360825839000c6d5d405d2e9a2122624963ea3ffeeb6570b31a790db08d6e3cd38d5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e821a001e8480a1581cc3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3a144434f494e05021a0002bf20031a02faf080081903e8a100d90102818258202543b92ff1095511476adc8369db6ddc933665a11978dda1404ee1066ca9559d5840b30b5102f898aa5a1dbd812b1f18119551e4f304c129369e66ca4955c0913073cb9f1e7ad3944e29e7eb72cb2fd4c3e7c91e369e943909dd40c3fb74a42c1c01f5f6"
+}`)
+	name := filepath.Join(t.TempDir(), "tx.signed")
+	if err := ioutil.WriteFile(name, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := envelope.ReadFile(name)
+	assert.NoError(t, err)
+	decoded, err := env.Tx()
+	assert.NoError(t, err)
+	invalid, err := decoded.VerifyWitnesses()
+	assert.NoError(t, err)
+	assert.Empty(t, invalid)
+
+	hash, err := env.TxHash()
+	assert.NoError(t, err)
+	txHash, err := decoded.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, hash, txHash)
+	assert.Equal(t, "463bf70ecf5b3210e66ac6966be41ae3cb1dec5c0cbdc83e0ec84de2015cf51e", hex.EncodeToString(txHash[:]))
+}
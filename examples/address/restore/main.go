@@ -18,7 +18,7 @@ import (
 	"github.com/fivebinaries/go-cardano-serialization/address"
 	"github.com/fivebinaries/go-cardano-serialization/bip32"
 	"github.com/fivebinaries/go-cardano-serialization/network"
-	"github.com/tyler-smith/go-bip39"
+	"github.com/fivebinaries/go-cardano-serialization/wallet"
 )
 
 const (
@@ -34,7 +34,7 @@ func init() {
 
 	flag.StringVar(&mnemFlag, "mnemonic", "", "Mnemonic to restore wallet")
 	flag.UintVar(&addrTypeFlag, "type", enterpriseType, "Enum of address type(0: Enterprise Address, 1: Base Address)")
-	flag.StringVar(&networkFlag, "network", "mainnet", "The network ie mainnet or testnet")
+	flag.StringVar(&networkFlag, "network", "mainnet", "The network ie mainnet, preprod or preview")
 	flag.Parse()
 
 	if mnemFlag == "" {
@@ -47,10 +47,6 @@ func init() {
 
 }
 
-func harden(num uint) uint32 {
-	return uint32(0x80000000 + num)
-}
-
 // checkHandleErr - utility
 func checkHandleErr(err error) {
 	if err != nil {
@@ -58,77 +54,33 @@ func checkHandleErr(err error) {
 	}
 }
 
-func generateEnterprise(net *network.NetworkInfo, entropy []byte) (addr *address.EnterpriseAddress, err error) {
-	rootKey := bip32.FromBip39Entropy(
-		entropy,
-		[]byte{},
-	)
-
-	accountKey := rootKey.Derive(harden(1852)).Derive(harden(1815)).Derive(harden(0))
-
-	utxoPubKey := accountKey.Derive(0).Derive(0).Public()
-	utxoPubKeyHash := utxoPubKey.PublicKey().Hash()
-
-	addr = address.NewEnterpriseAddress(
-		net,
-		address.NewKeyStakeCredential(utxoPubKeyHash[:]),
-	)
-	return
+func generateEnterprise(net *network.NetworkInfo, rootKey bip32.XPrv) (addr *address.EnterpriseAddress, err error) {
+	return wallet.NewWallet(rootKey).Account(0).EnterpriseAddress(net, wallet.ExternalRole, 0)
 }
 
-func generateBaseAddress(net *network.NetworkInfo, entropy []byte) (addr address.BaseAddress, err error) {
-	rootKey := bip32.FromBip39Entropy(
-		entropy,
-		[]byte{},
-	)
-
-	accountKey := rootKey.Derive(harden(1852)).Derive(harden(1815)).Derive(harden(0))
-
-	utxoPubKey := accountKey.Derive(0).Derive(0).Public()
-	utxoPubKeyHash := utxoPubKey.PublicKey().Hash()
-
-	stakeKey := accountKey.Derive(2).Derive(0).Public()
-	stakeKeyHash := stakeKey.PublicKey().Hash()
-
-	addr = *address.NewBaseAddress(
-		net,
-		&address.StakeCredential{
-			Kind:    address.KeyStakeCredentialType,
-			Payload: utxoPubKeyHash[:],
-		},
-		&address.StakeCredential{
-			Kind:    address.KeyStakeCredentialType,
-			Payload: stakeKeyHash[:],
-		})
-	return
+func generateBaseAddress(net *network.NetworkInfo, rootKey bip32.XPrv) (addr *address.BaseAddress, err error) {
+	return wallet.NewWallet(rootKey).Account(0).BaseAddress(net, wallet.ExternalRole, 0, 0)
 }
 
 func main() {
 
-	networks := make(map[string]*network.NetworkInfo)
-
-	networks["mainnet"] = network.MainNet()
-	networks["testnet"] = network.TestNet()
-
-	if _, ok := networks[networkFlag]; !ok {
+	registered, err := network.ByName(networkFlag)
+	if err != nil {
 		log.Fatalf("Unsupported network type (%s)", networkFlag)
 	}
-	net := networks[networkFlag]
+	net := &registered.Info
 
-	if addrTypeFlag == enterpriseType {
-		entropy, err := bip39.EntropyFromMnemonic(mnemFlag)
-		checkHandleErr(err)
+	rootKey, err := bip32.FromMnemonic(mnemFlag, []byte{})
+	checkHandleErr(err)
 
-		addr, err := generateEnterprise(net, entropy)
+	if addrTypeFlag == enterpriseType {
+		addr, err := generateEnterprise(net, rootKey)
 		checkHandleErr(err)
 
 		fmt.Printf("Enterprise Address: %s", addr.String())
 
 	} else if addrTypeFlag == baseType {
-		entropy, err := bip39.EntropyFromMnemonic(mnemFlag)
-		checkHandleErr(err)
-
-		addr, err := generateBaseAddress(net, entropy)
+		addr, err := generateBaseAddress(net, rootKey)
 		checkHandleErr(err)
 
 		fmt.Printf("Base Address: %s", addr.String())
@@ -12,7 +12,7 @@ import (
 	"github.com/fivebinaries/go-cardano-serialization/network"
 	"github.com/fivebinaries/go-cardano-serialization/node"
 	"github.com/fivebinaries/go-cardano-serialization/tx"
-	"github.com/tyler-smith/go-bip39"
+	"github.com/fivebinaries/go-cardano-serialization/wallet"
 )
 
 const (
@@ -45,38 +45,21 @@ func init() {
 
 // createRootKey returns a bip32 private key generated from 24 word (bip39) secret.
 func createRootKey(mnemonic string) bip32.XPrv {
-	entropy, _ := bip39.EntropyFromMnemonic(mnemonic)
-	rootKey := bip32.FromBip39Entropy(
-		entropy,
-		[]byte{},
-	)
+	rootKey, err := bip32.FromMnemonic(mnemonic, []byte{})
+	if err != nil {
+		log.Fatal(err)
+	}
 	return rootKey
 }
 
-func harden(num uint) uint32 {
-	return uint32(0x80000000 + num)
-}
-
 func generateBaseAddress(net *network.NetworkInfo, rootKey bip32.XPrv) (addr *address.BaseAddress, utxoPrvKey bip32.XPrv, err error) {
-	accountKey := rootKey.Derive(harden(1852)).Derive(harden(1815)).Derive(harden(0))
-
-	utxoPrvKey = accountKey.Derive(0).Derive(0)
-	utxoPubKey := utxoPrvKey.Public()
-	utxoPubKeyHash := utxoPubKey.PublicKey().Hash()
-
-	stakeKey := accountKey.Derive(2).Derive(0).Public()
-	stakeKeyHash := stakeKey.PublicKey().Hash()
-
-	addr = address.NewBaseAddress(
-		net,
-		&address.StakeCredential{
-			Kind:    address.KeyStakeCredentialType,
-			Payload: utxoPubKeyHash[:],
-		},
-		&address.StakeCredential{
-			Kind:    address.KeyStakeCredentialType,
-			Payload: stakeKeyHash[:],
-		})
+	account := wallet.NewWallet(rootKey).Account(0)
+
+	utxoPrvKey, err = account.PrivateKey(wallet.ExternalRole, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	addr, err = account.BaseAddress(net, wallet.ExternalRole, 0, 0)
 	return
 }
 
@@ -0,0 +1,80 @@
+package genesis
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+)
+
+// ExUnits is an amount of plutus execution units.
+type ExUnits struct {
+	Mem   uint64 `json:"exUnitsMem"`
+	Steps uint64 `json:"exUnitsSteps"`
+}
+
+// ExecutionPrices contains the prices of plutus execution units.
+type ExecutionPrices struct {
+	Steps Rational `json:"prSteps"`
+	Mem   Rational `json:"prMem"`
+}
+
+// CostModel is a list of plutus cost model parameters in their canonical order.
+type CostModel []int64
+
+// UnmarshalJSON deserializes a list of parameters or a map of named parameters into a CostModel.
+// Named parameters are ordered by name which is the canonical order of the plutus cost models.
+func (c *CostModel) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		var named map[string]int64
+		if err := json.Unmarshal(data, &named); err != nil {
+			return err
+		}
+		names := make([]string, 0, len(named))
+		for name := range named {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		model := make(CostModel, 0, len(names))
+		for _, name := range names {
+			model = append(model, named[name])
+		}
+		*c = model
+		return nil
+	}
+
+	var model []int64
+	if err := json.Unmarshal(data, &model); err != nil {
+		return err
+	}
+	*c = model
+	return nil
+}
+
+// AlonzoGenesis contains the content of an alonzo genesis file.
+type AlonzoGenesis struct {
+	LovelacePerUTxOWord  uint64               `json:"lovelacePerUTxOWord"`
+	ExecutionPrices      ExecutionPrices      `json:"executionPrices"`
+	MaxTxExUnits         ExUnits              `json:"maxTxExUnits"`
+	MaxBlockExUnits      ExUnits              `json:"maxBlockExUnits"`
+	MaxValueSize         uint64               `json:"maxValueSize"`
+	CollateralPercentage uint64               `json:"collateralPercentage"`
+	MaxCollateralInputs  uint64               `json:"maxCollateralInputs"`
+	CostModels           map[string]CostModel `json:"costModels"`
+}
+
+// LoadAlonzo returns a pointer to an AlonzoGenesis given the file path of an alonzo genesis file.
+func LoadAlonzo(fp string) (*AlonzoGenesis, error) {
+	data, err := ioutil.ReadFile(fp)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAlonzo(data)
+}
+
+// ParseAlonzo returns a pointer to an AlonzoGenesis unmarshalled from the json content of an alonzo genesis file.
+func ParseAlonzo(data []byte) (*AlonzoGenesis, error) {
+	g := &AlonzoGenesis{}
+	err := json.Unmarshal(data, g)
+	return g, err
+}
@@ -0,0 +1,92 @@
+package genesis
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"time"
+)
+
+// ByronProtocolConsts contains the protocol constants of the byron genesis.
+type ByronProtocolConsts struct {
+	K             uint64 `json:"k"`
+	ProtocolMagic uint32 `json:"protocolMagic"`
+	VssMinTTL     uint64 `json:"vssMinTTL"`
+	VssMaxTTL     uint64 `json:"vssMaxTTL"`
+}
+
+// ByronTxFeePolicy contains the byron linear fee parameters, scaled by 1e9.
+type ByronTxFeePolicy struct {
+	Multiplier Quantity `json:"multiplier"`
+	Summand    Quantity `json:"summand"`
+}
+
+// ByronBlockVersionData contains the initial byron protocol parameters.
+type ByronBlockVersionData struct {
+	HeavyDelThd       Quantity         `json:"heavyDelThd"`
+	MaxBlockSize      Quantity         `json:"maxBlockSize"`
+	MaxHeaderSize     Quantity         `json:"maxHeaderSize"`
+	MaxProposalSize   Quantity         `json:"maxProposalSize"`
+	MaxTxSize         Quantity         `json:"maxTxSize"`
+	MpcThd            Quantity         `json:"mpcThd"`
+	ScriptVersion     uint16           `json:"scriptVersion"`
+	SlotDuration      Quantity         `json:"slotDuration"`
+	TxFeePolicy       ByronTxFeePolicy `json:"txFeePolicy"`
+	UnlockStakeEpoch  Quantity         `json:"unlockStakeEpoch"`
+	UpdateImplicit    Quantity         `json:"updateImplicit"`
+	UpdateProposalThd Quantity         `json:"updateProposalThd"`
+	UpdateVoteThd     Quantity         `json:"updateVoteThd"`
+}
+
+// ByronHeavyDelegation is a genesis delegation certificate of the byron genesis.
+type ByronHeavyDelegation struct {
+	Omega      uint64 `json:"omega"`
+	IssuerPk   string `json:"issuerPk"`
+	DelegatePk string `json:"delegatePk"`
+	Cert       string `json:"cert"`
+}
+
+// ByronGenesis contains the content of a byron genesis file.
+type ByronGenesis struct {
+	StartTime        int64                           `json:"startTime"`
+	ProtocolConsts   ByronProtocolConsts             `json:"protocolConsts"`
+	BlockVersionData ByronBlockVersionData           `json:"blockVersionData"`
+	BootStakeholders map[string]uint64               `json:"bootStakeholders"`
+	HeavyDelegation  map[string]ByronHeavyDelegation `json:"heavyDelegation"`
+
+	// AvvmDistr maps base64url encoded AVVM redeem keys to their balance.
+	AvvmDistr map[string]Quantity `json:"avvmDistr"`
+
+	// NonAvvmBalances maps base58 encoded byron addresses to their balance.
+	NonAvvmBalances map[string]Quantity `json:"nonAvvmBalances"`
+}
+
+// LoadByron returns a pointer to a ByronGenesis given the file path of a byron genesis file.
+func LoadByron(fp string) (*ByronGenesis, error) {
+	data, err := ioutil.ReadFile(fp)
+	if err != nil {
+		return nil, err
+	}
+	return ParseByron(data)
+}
+
+// ParseByron returns a pointer to a ByronGenesis unmarshalled from the json content of a byron genesis file.
+func ParseByron(data []byte) (*ByronGenesis, error) {
+	g := &ByronGenesis{}
+	err := json.Unmarshal(data, g)
+	return g, err
+}
+
+// SystemStart returns the start time of the network.
+func (g *ByronGenesis) SystemStart() time.Time {
+	return time.Unix(g.StartTime, 0).UTC()
+}
+
+// SlotDuration returns the length of a byron slot.
+func (g *ByronGenesis) SlotDuration() time.Duration {
+	return time.Duration(g.BlockVersionData.SlotDuration) * time.Millisecond
+}
+
+// EpochLength returns the number of slots in a byron epoch, 10k for security parameter k.
+func (g *ByronGenesis) EpochLength() uint64 {
+	return g.ProtocolConsts.K * 10
+}
@@ -0,0 +1,80 @@
+package genesis
+
+import (
+	"encoding/json"
+	"io/ioutil"
+)
+
+// PoolVotingThresholds contains the stake pool operator voting thresholds of the conway genesis.
+type PoolVotingThresholds struct {
+	CommitteeNormal       Rational `json:"committeeNormal"`
+	CommitteeNoConfidence Rational `json:"committeeNoConfidence"`
+	HardForkInitiation    Rational `json:"hardForkInitiation"`
+	MotionNoConfidence    Rational `json:"motionNoConfidence"`
+	PPSecurityGroup       Rational `json:"ppSecurityGroup"`
+}
+
+// DRepVotingThresholds contains the delegate representative voting thresholds of the conway genesis.
+type DRepVotingThresholds struct {
+	MotionNoConfidence    Rational `json:"motionNoConfidence"`
+	CommitteeNormal       Rational `json:"committeeNormal"`
+	CommitteeNoConfidence Rational `json:"committeeNoConfidence"`
+	UpdateToConstitution  Rational `json:"updateToConstitution"`
+	HardForkInitiation    Rational `json:"hardForkInitiation"`
+	PPNetworkGroup        Rational `json:"ppNetworkGroup"`
+	PPEconomicGroup       Rational `json:"ppEconomicGroup"`
+	PPTechnicalGroup      Rational `json:"ppTechnicalGroup"`
+	PPGovGroup            Rational `json:"ppGovGroup"`
+	TreasuryWithdrawal    Rational `json:"treasuryWithdrawal"`
+}
+
+// Anchor references off-chain content by url and the hex encoded hash of the content.
+type Anchor struct {
+	URL      string `json:"url"`
+	DataHash string `json:"dataHash"`
+}
+
+// Constitution is the initial constitution of the conway genesis.
+type Constitution struct {
+	Anchor Anchor `json:"anchor"`
+	Script string `json:"script,omitempty"`
+}
+
+// Committee is the initial constitutional committee of the conway genesis. Members map
+// `keyHash-` or `scriptHash-` prefixed cold credentials to the epoch their term ends.
+type Committee struct {
+	Members   map[string]uint64 `json:"members"`
+	Threshold Rational          `json:"threshold"`
+}
+
+// ConwayGenesis contains the content of a conway genesis file.
+type ConwayGenesis struct {
+	PoolVotingThresholds       PoolVotingThresholds `json:"poolVotingThresholds"`
+	DRepVotingThresholds       DRepVotingThresholds `json:"dRepVotingThresholds"`
+	CommitteeMinSize           uint64               `json:"committeeMinSize"`
+	CommitteeMaxTermLength     uint64               `json:"committeeMaxTermLength"`
+	GovActionLifetime          uint64               `json:"govActionLifetime"`
+	GovActionDeposit           uint64               `json:"govActionDeposit"`
+	DRepDeposit                uint64               `json:"dRepDeposit"`
+	DRepActivity               uint64               `json:"dRepActivity"`
+	MinFeeRefScriptCostPerByte Rational             `json:"minFeeRefScriptCostPerByte"`
+	PlutusV3CostModel          CostModel            `json:"plutusV3CostModel"`
+	Constitution               Constitution         `json:"constitution"`
+	Committee                  Committee            `json:"committee"`
+}
+
+// LoadConway returns a pointer to a ConwayGenesis given the file path of a conway genesis file.
+func LoadConway(fp string) (*ConwayGenesis, error) {
+	data, err := ioutil.ReadFile(fp)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConway(data)
+}
+
+// ParseConway returns a pointer to a ConwayGenesis unmarshalled from the json content of a conway genesis file.
+func ParseConway(data []byte) (*ConwayGenesis, error) {
+	g := &ConwayGenesis{}
+	err := json.Unmarshal(data, g)
+	return g, err
+}
@@ -0,0 +1,192 @@
+package genesis
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/fivebinaries/go-cardano-serialization/network"
+	"github.com/fivebinaries/go-cardano-serialization/protocol"
+)
+
+var (
+	ErrMissingGenesis  = errors.New("missing genesis")
+	ErrInvalidRational = errors.New("invalid rational")
+)
+
+// Genesis bundles the genesis files of a network. Byron and Shelley genesis are required to derive
+// network parameters, Alonzo and Conway genesis are optional.
+type Genesis struct {
+	Byron   *ByronGenesis
+	Shelley *ShelleyGenesis
+	Alonzo  *AlonzoGenesis
+	Conway  *ConwayGenesis
+}
+
+// Load returns a pointer to a Genesis given the file paths of the byron, shelley, alonzo and conway
+// genesis files as referenced in the node configuration. Empty alonzo or conway paths are skipped.
+func Load(byronFp, shelleyFp, alonzoFp, conwayFp string) (*Genesis, error) {
+	g := &Genesis{}
+	var err error
+
+	if g.Byron, err = LoadByron(byronFp); err != nil {
+		return nil, err
+	}
+	if g.Shelley, err = LoadShelley(shelleyFp); err != nil {
+		return nil, err
+	}
+	if alonzoFp != "" {
+		if g.Alonzo, err = LoadAlonzo(alonzoFp); err != nil {
+			return nil, err
+		}
+	}
+	if conwayFp != "" {
+		if g.Conway, err = LoadConway(conwayFp); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// NetworkInfo returns the network id and protocol magic defined in the shelley genesis.
+func (g *Genesis) NetworkInfo() (*network.NetworkInfo, error) {
+	if g.Shelley == nil {
+		return nil, ErrMissingGenesis
+	}
+	return g.Shelley.NetworkInfo(), nil
+}
+
+// EraHistory returns the era history of the network given the epoch of the shelley hard fork,
+// `TestShelleyHardForkAtEpoch` in the node configuration of devnets.
+func (g *Genesis) EraHistory(shelleyStartEpoch uint64) (*network.EraHistory, error) {
+	if g.Byron == nil || g.Shelley == nil {
+		return nil, ErrMissingGenesis
+	}
+	return network.NewEraHistoryFromGenesis(
+		g.Shelley.SystemStart,
+		g.Byron.SlotDuration(),
+		g.Byron.ProtocolConsts.K,
+		shelleyStartEpoch,
+		g.Shelley.SlotDuration(),
+		g.Shelley.EpochLength,
+	)
+}
+
+// Network returns a network.Network which can be registered with network.Register so addresses and
+// time conversions work on the network described by the genesis files.
+func (g *Genesis) Network(name string, shelleyStartEpoch uint64) (*network.Network, error) {
+	info, err := g.NetworkInfo()
+	if err != nil {
+		return nil, err
+	}
+	eraHistory, err := g.EraHistory(shelleyStartEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	net := &network.Network{
+		Name:          name,
+		Info:          *info,
+		AddressPrefix: "addr_test",
+		StakePrefix:   "stake_test",
+		EraHistory:    eraHistory,
+	}
+	if info.NetworkId == network.MainNet().NetworkId {
+		net.AddressPrefix = "addr"
+		net.StakePrefix = "stake"
+	}
+	return net, nil
+}
+
+// Protocol returns the initial protocol parameters of the shelley genesis, including the
+// governance deposits of the conway genesis if loaded.
+func (g *Genesis) Protocol() (*protocol.Protocol, error) {
+	if g.Shelley == nil {
+		return nil, ErrMissingGenesis
+	}
+	params := g.Shelley.ProtocolParams
+	pr := &protocol.Protocol{
+		TxFeePerByte:    params.MinFeeA,
+		TxFeeFixed:      params.MinFeeB,
+		MaxTxSize:       params.MaxTxSize,
+		ProtocolVersion: params.ProtocolVersion,
+		MinUTXOValue:    params.MinUTxOValue,
+		KeyDeposit:      params.KeyDeposit,
+		PoolDeposit:     params.PoolDeposit,
+	}
+	if g.Conway != nil {
+		pr.DRepDeposit = uint(g.Conway.DRepDeposit)
+		pr.GovActionDeposit = uint(g.Conway.GovActionDeposit)
+	}
+	return pr, nil
+}
+
+// Rational is a fraction which is given in genesis files either as a decimal number
+// or as an object of numerator and denominator.
+type Rational struct {
+	Numerator   uint64 `json:"numerator"`
+	Denominator uint64 `json:"denominator"`
+}
+
+// Float64 returns the nearest float64 value of the rational.
+func (r Rational) Float64() float64 {
+	if r.Denominator == 0 {
+		return 0
+	}
+	f, _ := new(big.Rat).SetFrac(
+		new(big.Int).SetUint64(r.Numerator),
+		new(big.Int).SetUint64(r.Denominator),
+	).Float64()
+	return f
+}
+
+// UnmarshalJSON deserializes a decimal number or an object of numerator and denominator into a Rational.
+func (r *Rational) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		type rawRational Rational
+		var raw rawRational
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		*r = Rational(raw)
+		return nil
+	}
+
+	rat, ok := new(big.Rat).SetString(string(data))
+	if !ok || rat.Sign() < 0 || !rat.Num().IsUint64() || !rat.Denom().IsUint64() {
+		return ErrInvalidRational
+	}
+	*r = Rational{
+		Numerator:   rat.Num().Uint64(),
+		Denominator: rat.Denom().Uint64(),
+	}
+	return nil
+}
+
+// Quantity is an unsigned integer, e.g. an amount of lovelace, which byron genesis files encode as a json string.
+type Quantity uint64
+
+// UnmarshalJSON deserializes a json string or number into a Quantity.
+func (l *Quantity) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		var num uint64
+		if err := json.Unmarshal(data, &num); err != nil {
+			return err
+		}
+		*l = Quantity(num)
+		return nil
+	}
+	num, err := strconv.ParseUint(str, 10, 64)
+	if err != nil {
+		return err
+	}
+	*l = Quantity(num)
+	return nil
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,110 @@
+package genesis_test
+
+import (
+	"encoding/hex"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/genesis"
+	"github.com/fivebinaries/go-cardano-serialization/network"
+	"github.com/fivebinaries/go-cardano-serialization/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_, b, _, _  = runtime.Caller(0)
+	packagepath = filepath.Dir(b)
+	genesispath = filepath.Join(filepath.Dir(packagepath), "testdata", "genesis")
+)
+
+func loadGenesis(t *testing.T) *genesis.Genesis {
+	g, err := genesis.Load(
+		filepath.Join(genesispath, "byron-genesis.json"),
+		filepath.Join(genesispath, "shelley-genesis.json"),
+		filepath.Join(genesispath, "alonzo-genesis.json"),
+		filepath.Join(genesispath, "conway-genesis.json"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestGenesisParams(t *testing.T) {
+	g := loadGenesis(t)
+
+	info, err := g.NetworkInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, network.NetworkInfo{NetworkId: 0, ProtocolMagic: 42}, *info)
+
+	pr, err := g.Protocol()
+	assert.NoError(t, err)
+	assert.Equal(t, protocol.Protocol{
+		TxFeePerByte:     44,
+		TxFeeFixed:       155381,
+		MaxTxSize:        16384,
+		ProtocolVersion:  protocol.ProtocolVersion{Major: 2, Minor: 0},
+		MinUTXOValue:     1000000,
+		KeyDeposit:       2000000,
+		PoolDeposit:      500000000,
+		DRepDeposit:      500000000,
+		GovActionDeposit: 100000000000,
+	}, *pr)
+
+	assert.Equal(t, 20*time.Second, g.Byron.SlotDuration())
+	assert.Equal(t, uint64(21600), g.Byron.EpochLength())
+	assert.Equal(t, genesis.Rational{Numerator: 1, Denominator: 20}, g.Shelley.ActiveSlotsCoeff)
+	assert.Equal(t, genesis.Rational{Numerator: 721, Denominator: 10000000}, g.Alonzo.ExecutionPrices.Steps)
+	assert.Equal(t, genesis.CostModel{197209, 0, 4}, g.Alonzo.CostModels["PlutusV1"])
+	assert.Equal(t, genesis.Rational{Numerator: 2, Denominator: 3}, g.Conway.Committee.Threshold)
+	assert.Equal(t, genesis.Rational{Numerator: 3, Denominator: 4}, g.Conway.DRepVotingThresholds.PPGovGroup)
+
+	net, err := g.Network("devnet-42", 4)
+	assert.NoError(t, err)
+	assert.Equal(t, "addr_test", net.AddressPrefix)
+	assert.Equal(t, time.Date(2022, 6, 21, 0, 0, 0, 0, time.UTC), net.EraHistory.SlotToTime(86400))
+	assert.Equal(t, time.Date(2022, 6, 26, 0, 0, 0, 0, time.UTC), net.EraHistory.EpochToTime(5))
+}
+
+func TestGenesisUTxO(t *testing.T) {
+	g := loadGenesis(t)
+
+	utxos, err := g.InitialUTxO()
+	assert.NoError(t, err)
+	assert.Len(t, utxos, 3)
+
+	byTxHash := map[string]genesis.UTxO{}
+	for _, utxo := range utxos {
+		assert.Equal(t, uint16(0), utxo.Input.Index)
+		assert.Equal(t, utxo.Input.Amount, utxo.Output.Amount)
+		byTxHash[hex.EncodeToString(utxo.Input.TxHash)] = utxo
+	}
+
+	shelley := byTxHash["ac5537d789d5f6a03fe4edc794b7e49f1871e4ba6b3037b971e56c23dd2710b6"]
+	if assert.NotNil(t, shelley.Output) {
+		assert.Equal(t, uint(1000000000000), shelley.Output.Amount)
+		assert.IsType(t, &address.BaseAddress{}, shelley.Output.Address)
+	}
+
+	nonAvvm := byTxHash["4c88ce3732479bf90f138a5ab02c91dd26c828c0094ea78065867620a7a9aace"]
+	if assert.NotNil(t, nonAvvm.Output) {
+		assert.Equal(t, uint(30000000000000), nonAvvm.Output.Amount)
+		assert.Equal(t, "Ae2tdPwUPEZFRbyhz3cpfC2CumGzNkFBN2L42rcUc2yjQpEkxDbkPodpMAi", nonAvvm.Output.Address.String())
+	}
+
+	redeemCount := 0
+	for _, utxo := range utxos {
+		redeem, ok := utxo.Output.Address.(*address.ByronAddress)
+		if !ok || redeem.Tag != address.ByronRedeemAddressType {
+			continue
+		}
+		assert.Equal(t, uint(9999300000000), utxo.Output.Amount)
+		assert.Equal(t, "2d536dd5daee059e76ff3c66d5f3b0ceb26f9a19ef5dd8ac9ddf6d11", hex.EncodeToString(redeem.Hash))
+		assert.Equal(t, uint32(42), redeem.NetworkInfo().ProtocolMagic)
+		redeemCount++
+	}
+	assert.Equal(t, 1, redeemCount)
+}
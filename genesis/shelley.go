@@ -0,0 +1,97 @@
+package genesis
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"time"
+
+	"github.com/fivebinaries/go-cardano-serialization/network"
+	"github.com/fivebinaries/go-cardano-serialization/protocol"
+)
+
+// ShelleyProtocolParams contains the initial protocol parameters of the shelley genesis.
+type ShelleyProtocolParams struct {
+	MinFeeA               uint                     `json:"minFeeA"`
+	MinFeeB               uint                     `json:"minFeeB"`
+	MaxBlockBodySize      uint                     `json:"maxBlockBodySize"`
+	MaxTxSize             uint                     `json:"maxTxSize"`
+	MaxBlockHeaderSize    uint                     `json:"maxBlockHeaderSize"`
+	KeyDeposit            uint                     `json:"keyDeposit"`
+	PoolDeposit           uint                     `json:"poolDeposit"`
+	EMax                  uint                     `json:"eMax"`
+	NOpt                  uint                     `json:"nOpt"`
+	A0                    Rational                 `json:"a0"`
+	Rho                   Rational                 `json:"rho"`
+	Tau                   Rational                 `json:"tau"`
+	DecentralisationParam Rational                 `json:"decentralisationParam"`
+	ExtraEntropy          json.RawMessage          `json:"extraEntropy"`
+	ProtocolVersion       protocol.ProtocolVersion `json:"protocolVersion"`
+	MinUTxOValue          uint                     `json:"minUTxOValue"`
+	MinPoolCost           uint                     `json:"minPoolCost"`
+}
+
+// ShelleyGenDeleg is a genesis key delegation of the shelley genesis.
+type ShelleyGenDeleg struct {
+	Delegate string `json:"delegate"`
+	Vrf      string `json:"vrf"`
+}
+
+// ShelleyStaking contains the initial stake pools and stake delegations of the shelley genesis.
+type ShelleyStaking struct {
+	Pools map[string]json.RawMessage `json:"pools"`
+	Stake map[string]string          `json:"stake"`
+}
+
+// ShelleyGenesis contains the content of a shelley genesis file.
+type ShelleyGenesis struct {
+	SystemStart       time.Time                  `json:"systemStart"`
+	NetworkMagic      uint32                     `json:"networkMagic"`
+	NetworkId         string                     `json:"networkId"`
+	ActiveSlotsCoeff  Rational                   `json:"activeSlotsCoeff"`
+	SecurityParam     uint64                     `json:"securityParam"`
+	EpochLength       uint64                     `json:"epochLength"`
+	SlotsPerKESPeriod uint64                     `json:"slotsPerKESPeriod"`
+	MaxKESEvolutions  uint64                     `json:"maxKESEvolutions"`
+	SlotLength        float64                    `json:"slotLength"`
+	UpdateQuorum      uint64                     `json:"updateQuorum"`
+	MaxLovelaceSupply uint64                     `json:"maxLovelaceSupply"`
+	ProtocolParams    ShelleyProtocolParams      `json:"protocolParams"`
+	GenDelegs         map[string]ShelleyGenDeleg `json:"genDelegs"`
+	Staking           *ShelleyStaking            `json:"staking"`
+
+	// InitialFunds maps hex encoded addresses to their balance.
+	InitialFunds map[string]uint64 `json:"initialFunds"`
+}
+
+// LoadShelley returns a pointer to a ShelleyGenesis given the file path of a shelley genesis file.
+func LoadShelley(fp string) (*ShelleyGenesis, error) {
+	data, err := ioutil.ReadFile(fp)
+	if err != nil {
+		return nil, err
+	}
+	return ParseShelley(data)
+}
+
+// ParseShelley returns a pointer to a ShelleyGenesis unmarshalled from the json content of a shelley genesis file.
+func ParseShelley(data []byte) (*ShelleyGenesis, error) {
+	g := &ShelleyGenesis{}
+	err := json.Unmarshal(data, g)
+	return g, err
+}
+
+// NetworkInfo returns the network id and protocol magic of the network.
+func (g *ShelleyGenesis) NetworkInfo() *network.NetworkInfo {
+	info := &network.NetworkInfo{
+		NetworkId:     network.TestNet().NetworkId,
+		ProtocolMagic: g.NetworkMagic,
+	}
+	if g.NetworkId == "Mainnet" {
+		info.NetworkId = network.MainNet().NetworkId
+	}
+	return info
+}
+
+// SlotDuration returns the length of a shelley slot.
+func (g *ShelleyGenesis) SlotDuration() time.Duration {
+	return seconds(g.SlotLength)
+}
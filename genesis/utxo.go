@@ -0,0 +1,124 @@
+package genesis
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fivebinaries/go-cardano-serialization/network"
+	"github.com/fivebinaries/go-cardano-serialization/tx"
+)
+
+// UTxO is an unspent transaction output created by a genesis file.
+type UTxO struct {
+	Input  *tx.TxInput
+	Output *tx.TxOutput
+}
+
+// newGenesisUTxO returns the genesis utxo of an address. Genesis outputs are spent from a pseudo
+// transaction whose hash is the blake2b256 hash of the address bytes, at output index 0.
+func newGenesisUTxO(addr address.Address, amount uint64) UTxO {
+	txHash := crypto.Blake2b256(addr.Bytes())
+	return UTxO{
+		Input: &tx.TxInput{
+			TxHash: txHash[:],
+			Index:  0,
+			Amount: uint(amount),
+		},
+		Output: tx.NewTxOutput(addr, uint(amount)),
+	}
+}
+
+func sortUTxO(utxos []UTxO) {
+	sort.Slice(utxos, func(i, j int) bool {
+		return bytes.Compare(utxos[i].Input.TxHash, utxos[j].Input.TxHash) < 0
+	})
+}
+
+// NetworkInfo returns the network of the byron genesis derived from its protocol magic.
+func (g *ByronGenesis) NetworkInfo() *network.NetworkInfo {
+	if g.ProtocolConsts.ProtocolMagic == network.MainNetMagic {
+		return network.MainNet()
+	}
+	return &network.NetworkInfo{
+		NetworkId:     network.TestNet().NetworkId,
+		ProtocolMagic: g.ProtocolConsts.ProtocolMagic,
+	}
+}
+
+// UTxO returns the utxo of the AVVM and non-AVVM balances of the byron genesis sorted by transaction hash.
+func (g *ByronGenesis) UTxO() ([]UTxO, error) {
+	utxos := make([]UTxO, 0, len(g.AvvmDistr)+len(g.NonAvvmBalances))
+
+	for key, amount := range g.AvvmDistr {
+		redeemKey, err := base64.URLEncoding.DecodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid avvm key %s: %w", key, err)
+		}
+		addr, err := address.NewByronRedeemAddress(g.NetworkInfo(), redeemKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid avvm key %s: %w", key, err)
+		}
+		utxos = append(utxos, newGenesisUTxO(addr, uint64(amount)))
+	}
+
+	for addrStr, amount := range g.NonAvvmBalances {
+		data := base58.Decode(addrStr)
+		if len(data) == 0 {
+			return nil, fmt.Errorf("invalid non-avvm address %s", addrStr)
+		}
+		addr, err := address.NewAddressFromBytes(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid non-avvm address %s: %w", addrStr, err)
+		}
+		utxos = append(utxos, newGenesisUTxO(addr, uint64(amount)))
+	}
+
+	sortUTxO(utxos)
+	return utxos, nil
+}
+
+// UTxO returns the utxo of the initial funds of the shelley genesis sorted by transaction hash.
+func (g *ShelleyGenesis) UTxO() ([]UTxO, error) {
+	utxos := make([]UTxO, 0, len(g.InitialFunds))
+
+	for addrHex, amount := range g.InitialFunds {
+		data, err := hex.DecodeString(addrHex)
+		if err != nil || len(data) == 0 {
+			return nil, fmt.Errorf("invalid initial funds address %s", addrHex)
+		}
+		addr, err := address.NewAddressFromBytes(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid initial funds address %s: %w", addrHex, err)
+		}
+		utxos = append(utxos, newGenesisUTxO(addr, amount))
+	}
+
+	sortUTxO(utxos)
+	return utxos, nil
+}
+
+// InitialUTxO returns the utxo created by the byron and shelley genesis.
+func (g *Genesis) InitialUTxO() ([]UTxO, error) {
+	var utxos []UTxO
+	if g.Byron != nil {
+		byronUTxO, err := g.Byron.UTxO()
+		if err != nil {
+			return nil, err
+		}
+		utxos = append(utxos, byronUTxO...)
+	}
+	if g.Shelley != nil {
+		shelleyUTxO, err := g.Shelley.UTxO()
+		if err != nil {
+			return nil, err
+		}
+		utxos = append(utxos, shelleyUTxO...)
+	}
+	return utxos, nil
+}
@@ -0,0 +1,177 @@
+// Package keystore stores extended private keys in password encrypted files.
+//
+// Keys are encrypted with ChaCha20-Poly1305 under a key derived from the password with scrypt. The public key
+// and derivation path are stored in plain text and authenticated with the encrypted key.
+package keystore
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// Version is the version of the keystore format.
+	Version = 1
+
+	KDFScrypt              = "scrypt"
+	CipherChaCha20Poly1305 = "chacha20-poly1305"
+
+	// Default scrypt parameters, requiring 32 MiB of memory.
+	DefaultScryptN = 1 << 15
+	DefaultScryptR = 8
+	DefaultScryptP = 1
+
+	saltLen = 32
+)
+
+var (
+	ErrWrongPassword       = errors.New("wrong password or corrupted keystore")
+	ErrUnsupportedKeystore = errors.New("unsupported keystore version, kdf or cipher")
+)
+
+// HexBytes is a byte slice encoded as hex in json.
+type HexBytes []byte
+
+// MarshalText returns the hex encoding of the bytes.
+func (b HexBytes) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(b)), nil
+}
+
+// UnmarshalText decodes hex encoded bytes.
+func (b *HexBytes) UnmarshalText(text []byte) error {
+	decoded, err := hex.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// ScryptParams are the parameters of the scrypt key derivation.
+type ScryptParams struct {
+	N    int      `json:"n"`
+	R    int      `json:"r"`
+	P    int      `json:"p"`
+	Salt HexBytes `json:"salt"`
+}
+
+// Crypto describes the encryption of the key.
+type Crypto struct {
+	KDF        string       `json:"kdf"`
+	KDFParams  ScryptParams `json:"kdfparams"`
+	Cipher     string       `json:"cipher"`
+	Nonce      HexBytes     `json:"nonce"`
+	Ciphertext HexBytes     `json:"ciphertext"`
+}
+
+// Keystore is an extended private key encrypted with a password.
+type Keystore struct {
+	Version   int                  `json:"version"`
+	Path      bip32.DerivationPath `json:"path"`
+	PublicKey HexBytes             `json:"public_key"`
+	Crypto    Crypto               `json:"crypto"`
+}
+
+// New returns a pointer to a new Keystore of the key at the derivation path encrypted with the password.
+func New(key bip32.XPrv, path bip32.DerivationPath, password []byte) (*Keystore, error) {
+	return NewWithScrypt(key, path, password, DefaultScryptN, DefaultScryptR, DefaultScryptP)
+}
+
+// NewWithScrypt returns a pointer to a new Keystore encrypted with a key derived with the scrypt parameters.
+func NewWithScrypt(key bip32.XPrv, path bip32.DerivationPath, password []byte, n, r, p int) (*Keystore, error) {
+	k := &Keystore{
+		Version:   Version,
+		Path:      path,
+		PublicKey: HexBytes(key.Public()),
+		Crypto: Crypto{
+			KDF:       KDFScrypt,
+			KDFParams: ScryptParams{N: n, R: r, P: p, Salt: make([]byte, saltLen)},
+			Cipher:    CipherChaCha20Poly1305,
+			Nonce:     make([]byte, chacha20poly1305.NonceSize),
+		},
+	}
+	if _, err := rand.Read(k.Crypto.KDFParams.Salt); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(k.Crypto.Nonce); err != nil {
+		return nil, err
+	}
+
+	aead, err := k.aead(password)
+	if err != nil {
+		return nil, err
+	}
+	k.Crypto.Ciphertext = aead.Seal(nil, k.Crypto.Nonce, key, k.additionalData())
+	return k, nil
+}
+
+// aead returns the cipher keyed with the password.
+func (k *Keystore) aead(password []byte) (cipher.AEAD, error) {
+	params := k.Crypto.KDFParams
+	key, err := scrypt.Key(password, params.Salt, params.N, params.R, params.P, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	return chacha20poly1305.New(key)
+}
+
+// additionalData returns the plain text fields authenticated with the key.
+func (k *Keystore) additionalData() []byte {
+	return append(append([]byte{}, k.PublicKey...), k.Path.String()...)
+}
+
+// XPub returns the extended public key of the stored key.
+func (k *Keystore) XPub() bip32.XPub {
+	return bip32.XPub(k.PublicKey)
+}
+
+// Decrypt returns the extended private key of the keystore decrypted with the password.
+func (k *Keystore) Decrypt(password []byte) (bip32.XPrv, error) {
+	if k.Version != Version || k.Crypto.KDF != KDFScrypt || k.Crypto.Cipher != CipherChaCha20Poly1305 {
+		return nil, ErrUnsupportedKeystore
+	}
+	if len(k.Crypto.Nonce) != chacha20poly1305.NonceSize {
+		return nil, ErrWrongPassword
+	}
+
+	aead, err := k.aead(password)
+	if err != nil {
+		return nil, err
+	}
+	key, err := aead.Open(nil, k.Crypto.Nonce, k.Crypto.Ciphertext, k.additionalData())
+	if err != nil || len(key) != bip32.XPrv_Size || !bytes.Equal(bip32.XPrv(key).Public(), k.PublicKey) {
+		return nil, ErrWrongPassword
+	}
+	return key, nil
+}
+
+// ReadFile reads the keystore stored in the file.
+func ReadFile(name string) (*Keystore, error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	k := &Keystore{}
+	if err := json.Unmarshal(data, k); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// WriteFile writes the keystore to the file, readable only by its owner.
+func (k *Keystore) WriteFile(name string) error {
+	data, err := json.MarshalIndent(k, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(name, data, 0600)
+}
@@ -0,0 +1,70 @@
+package keystore_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/fivebinaries/go-cardano-serialization/keystore"
+	"github.com/stretchr/testify/assert"
+)
+
+func createAccountKey(t *testing.T) (bip32.XPrv, bip32.DerivationPath) {
+	rootKey, err := bip32.FromMnemonic("test walk nut penalty hip pave soap entry language right filter choice", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err := bip32.ParseDerivationPath("m/1852'/1815'/0'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rootKey.DerivePath(path), path
+}
+
+func TestKeystore(t *testing.T) {
+	key, path := createAccountKey(t)
+
+	ks, err := keystore.New(key, path, []byte("password"))
+	assert.NoError(t, err)
+	assert.Equal(t, key.Public(), ks.XPub())
+
+	name := filepath.Join(t.TempDir(), "account.json")
+	assert.NoError(t, ks.WriteFile(name))
+	loaded, err := keystore.ReadFile(name)
+	assert.NoError(t, err)
+	assert.Equal(t, ks, loaded)
+	assert.Equal(t, "m/1852'/1815'/0'", loaded.Path.String())
+
+	decrypted, err := loaded.Decrypt([]byte("password"))
+	assert.NoError(t, err)
+	assert.Equal(t, key, decrypted)
+
+	_, err = loaded.Decrypt([]byte("wrong"))
+	assert.ErrorIs(t, err, keystore.ErrWrongPassword)
+}
+
+func TestKeystoreAuthentication(t *testing.T) {
+	key, path := createAccountKey(t)
+
+	ks, err := keystore.NewWithScrypt(key, path, []byte("password"), 1<<10, 8, 1)
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(ks)
+	assert.NoError(t, err)
+	var fields map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, "m/1852'/1815'/0'", fields["path"])
+	assert.Len(t, fields["public_key"], 128)
+	assert.Equal(t, "scrypt", fields["crypto"].(map[string]interface{})["kdf"])
+
+	// The path and public key cannot be changed without the password.
+	ks.Path = append(path, 0)
+	_, err = ks.Decrypt([]byte("password"))
+	assert.ErrorIs(t, err, keystore.ErrWrongPassword)
+	ks.Path = path
+
+	ks.Crypto.Cipher = "aes-128-ctr"
+	_, err = ks.Decrypt([]byte("password"))
+	assert.ErrorIs(t, err, keystore.ErrUnsupportedKeystore)
+}
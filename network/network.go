@@ -1,20 +1,91 @@
 package network
 
+// Protocol magics of the public cardano networks.
+const (
+	MainNetMagic   uint32 = 764824073
+	TestNetMagic   uint32 = 1097911063
+	PreProdMagic   uint32 = 1
+	PreviewMagic   uint32 = 2
+	SanchoNetMagic uint32 = 4
+)
+
 type NetworkInfo struct {
 	NetworkId     byte
 	ProtocolMagic uint32
 }
 
+// TestNet returns the NetworkInfo of the retired legacy testnet.
 func TestNet() *NetworkInfo {
 	return &NetworkInfo{
 		NetworkId:     0b0000,
-		ProtocolMagic: 1097911063,
+		ProtocolMagic: TestNetMagic,
 	}
 }
 
 func MainNet() *NetworkInfo {
 	return &NetworkInfo{
 		NetworkId:     0b0001,
-		ProtocolMagic: 764824073,
+		ProtocolMagic: MainNetMagic,
+	}
+}
+
+// PreProd returns the NetworkInfo of the pre-production testnet.
+func PreProd() *NetworkInfo {
+	return &NetworkInfo{
+		NetworkId:     0b0000,
+		ProtocolMagic: PreProdMagic,
+	}
+}
+
+// Preview returns the NetworkInfo of the preview testnet.
+func Preview() *NetworkInfo {
+	return &NetworkInfo{
+		NetworkId:     0b0000,
+		ProtocolMagic: PreviewMagic,
+	}
+}
+
+// SanchoNet returns the NetworkInfo of the governance testnet.
+func SanchoNet() *NetworkInfo {
+	return &NetworkInfo{
+		NetworkId:     0b0000,
+		ProtocolMagic: SanchoNetMagic,
+	}
+}
+
+// IsMainNet reports whether the NetworkInfo describes the cardano mainnet.
+func (n NetworkInfo) IsMainNet() bool {
+	return n == *MainNet()
+}
+
+// Name returns the registered name of the network or an empty string if the network is unknown.
+func (n NetworkInfo) Name() string {
+	if net, err := ByMagic(n.ProtocolMagic); err == nil && net.Info == n {
+		return net.Name
+	}
+	return ""
+}
+
+// AddressPrefix returns the bech32 prefix of payment addresses on the network. Unregistered
+// networks fall back to `addr` for mainnet network id and `addr_test` otherwise.
+func (n NetworkInfo) AddressPrefix() string {
+	if net, err := ByMagic(n.ProtocolMagic); err == nil && net.Info.NetworkId == n.NetworkId {
+		return net.AddressPrefix
+	}
+	if n.NetworkId == MainNet().NetworkId {
+		return "addr"
+	}
+	return "addr_test"
+}
+
+// StakePrefix returns the bech32 prefix of reward addresses on the network. Unregistered
+// networks fall back to `stake` for mainnet network id and `stake_test` otherwise.
+func (n NetworkInfo) StakePrefix() string {
+	if net, err := ByMagic(n.ProtocolMagic); err == nil && net.Info.NetworkId == n.NetworkId {
+		return net.StakePrefix
+	}
+	if n.NetworkId == MainNet().NetworkId {
+		return "stake"
 	}
+	return "stake_test"
 }
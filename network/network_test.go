@@ -0,0 +1,117 @@
+package network_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fivebinaries/go-cardano-serialization/network"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkRegistry(t *testing.T) {
+	for _, name := range []string{"mainnet", "testnet", "preprod", "preview", "sanchonet"} {
+		net, err := network.ByName(name)
+		assert.NoError(t, err)
+		assert.Equal(t, name, net.Info.Name())
+	}
+
+	preprod, err := network.ByMagic(network.PreProdMagic)
+	assert.NoError(t, err)
+	assert.Equal(t, *network.PreProd(), preprod.Info)
+	assert.Equal(t, "addr_test", network.PreProd().AddressPrefix())
+	assert.Equal(t, "stake", network.MainNet().StakePrefix())
+
+	devnet := network.Network{
+		Name:          "devnet",
+		Info:          network.NetworkInfo{NetworkId: 0, ProtocolMagic: 42},
+		AddressPrefix: "addr_dev",
+		StakePrefix:   "stake_dev",
+	}
+	assert.NoError(t, network.Register(devnet))
+	t.Cleanup(func() { network.Unregister(devnet.Name) })
+	assert.ErrorIs(t, network.Register(devnet), network.ErrNetworkExists)
+	assert.Equal(t, "addr_dev", devnet.Info.AddressPrefix())
+	assert.Equal(t, "stake_dev", devnet.Info.StakePrefix())
+
+	assert.NoError(t, network.Unregister(devnet.Name))
+	_, err = network.ByMagic(42)
+	assert.ErrorIs(t, err, network.ErrNetworkNotFound)
+	assert.ErrorIs(t, network.Unregister(devnet.Name), network.ErrNetworkNotFound)
+	assert.NoError(t, network.Register(devnet))
+
+	_, err = network.ByMagic(1234)
+	assert.ErrorIs(t, err, network.ErrNetworkNotFound)
+	assert.Equal(t, "addr_test", network.NetworkInfo{NetworkId: 0, ProtocolMagic: 1234}.AddressPrefix())
+}
+
+func TestEraHistory(t *testing.T) {
+	type timeTestCase struct {
+		description string
+		network     *network.NetworkInfo
+		slot        uint64
+		time        time.Time
+		epoch       uint64
+	}
+	scenarios := []timeTestCase{
+		{
+			description: "mainnet system start",
+			network:     network.MainNet(),
+			slot:        0,
+			time:        time.Date(2017, 9, 23, 21, 44, 51, 0, time.UTC),
+			epoch:       0,
+		},
+		{
+			description: "mainnet first shelley slot",
+			network:     network.MainNet(),
+			slot:        4492800,
+			time:        time.Date(2020, 7, 29, 21, 44, 51, 0, time.UTC),
+			epoch:       208,
+		},
+		{
+			description: "mainnet shelley slot",
+			network:     network.MainNet(),
+			slot:        72316896,
+			time:        time.Unix(72316896+1591566291, 0).UTC(),
+			epoch:       365,
+		},
+		{
+			description: "preprod shelley slot",
+			network:     network.PreProd(),
+			slot:        86400 + 432000,
+			time:        time.Unix(86400+432000+1655683200, 0).UTC(),
+			epoch:       5,
+		},
+		{
+			description: "preview slot",
+			network:     network.Preview(),
+			slot:        86400*10 + 5,
+			time:        time.Unix(86400*10+5+1666656000, 0).UTC(),
+			epoch:       10,
+		},
+	}
+
+	for _, sc := range scenarios {
+		t.Run(sc.description, func(t *testing.T) {
+			h, err := sc.network.EraHistory()
+			assert.NoError(t, err)
+
+			assert.Equal(t, sc.time, h.SlotToTime(sc.slot))
+
+			slot, err := h.TimeToSlot(sc.time)
+			assert.NoError(t, err)
+			assert.Equal(t, sc.slot, slot)
+
+			epoch, _ := h.SlotToEpoch(sc.slot)
+			assert.Equal(t, sc.epoch, epoch)
+			assert.LessOrEqual(t, h.EpochToSlot(epoch), sc.slot)
+			assert.Less(t, sc.slot, h.EpochToSlot(epoch+1))
+		})
+	}
+
+	h, _ := network.MainNet().EraHistory()
+	_, err := h.TimeToSlot(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, network.ErrBeforeSystemStart)
+
+	_, err = network.NetworkInfo{ProtocolMagic: 1234}.EraHistory()
+	assert.ErrorIs(t, err, network.ErrNoEraHistory)
+}
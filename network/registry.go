@@ -0,0 +1,174 @@
+package network
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrNetworkNotFound = errors.New("network not found")
+	ErrNetworkExists   = errors.New("network already registered")
+	ErrInvalidNetwork  = errors.New("invalid network")
+)
+
+// Network describes a named cardano network together with the bech32 prefixes of its addresses
+// and, if known, the era history used for slot and time conversions.
+type Network struct {
+	Name          string
+	Info          NetworkInfo
+	AddressPrefix string
+	StakePrefix   string
+	EraHistory    *EraHistory
+}
+
+var registry = struct {
+	sync.RWMutex
+	byMagic map[uint32]Network
+	byName  map[string]uint32
+}{
+	byMagic: map[uint32]Network{},
+	byName:  map[string]uint32{},
+}
+
+func init() {
+	for _, net := range []Network{
+		{
+			Name: "mainnet", Info: *MainNet(), AddressPrefix: "addr", StakePrefix: "stake",
+			EraHistory: mustEraHistoryFromGenesis("2017-09-23T21:44:51Z", 208, 432000),
+		},
+		{
+			Name: "testnet", Info: *TestNet(), AddressPrefix: "addr_test", StakePrefix: "stake_test",
+			EraHistory: mustEraHistoryFromGenesis("2019-07-24T20:20:16Z", 74, 432000),
+		},
+		{
+			Name: "preprod", Info: *PreProd(), AddressPrefix: "addr_test", StakePrefix: "stake_test",
+			EraHistory: mustEraHistoryFromGenesis("2022-06-01T00:00:00Z", 4, 432000),
+		},
+		{
+			Name: "preview", Info: *Preview(), AddressPrefix: "addr_test", StakePrefix: "stake_test",
+			EraHistory: mustEraHistoryFromGenesis("2022-10-25T00:00:00Z", 0, 86400),
+		},
+		{
+			Name: "sanchonet", Info: *SanchoNet(), AddressPrefix: "addr_test", StakePrefix: "stake_test",
+			EraHistory: mustEraHistoryFromGenesis("2023-06-15T00:30:00Z", 0, 86400),
+		},
+	} {
+		if err := Register(net); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Register adds a custom network, e.g. a private devnet, to the registry so it can be
+// looked up by name or protocol magic. Empty prefixes default to `addr_test`/`stake_test`.
+func Register(net Network) error {
+	if net.Name == "" || net.Info.NetworkId > 0xF {
+		return ErrInvalidNetwork
+	}
+	if net.AddressPrefix == "" {
+		net.AddressPrefix = "addr_test"
+	}
+	if net.StakePrefix == "" {
+		net.StakePrefix = "stake_test"
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, ok := registry.byMagic[net.Info.ProtocolMagic]; ok {
+		return ErrNetworkExists
+	}
+	if _, ok := registry.byName[net.Name]; ok {
+		return ErrNetworkExists
+	}
+	registry.byMagic[net.Info.ProtocolMagic] = net
+	registry.byName[net.Name] = net.Info.ProtocolMagic
+	return nil
+}
+
+// Unregister removes the network with the given name from the registry, e.g. a devnet which was torn down.
+func Unregister(name string) error {
+	registry.Lock()
+	defer registry.Unlock()
+
+	magic, ok := registry.byName[name]
+	if !ok {
+		return ErrNetworkNotFound
+	}
+	delete(registry.byName, name)
+	delete(registry.byMagic, magic)
+	return nil
+}
+
+// ByMagic returns the registered network with the given protocol magic.
+func ByMagic(magic uint32) (*Network, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	net, ok := registry.byMagic[magic]
+	if !ok {
+		return nil, ErrNetworkNotFound
+	}
+	return &net, nil
+}
+
+// ByName returns the registered network with the given name, e.g. `preprod`.
+func ByName(name string) (*Network, error) {
+	registry.RLock()
+	magic, ok := registry.byName[name]
+	registry.RUnlock()
+	if !ok {
+		return nil, ErrNetworkNotFound
+	}
+	return ByMagic(magic)
+}
+
+// Networks returns all registered networks ordered by protocol magic.
+func Networks() []Network {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	nets := make([]Network, 0, len(registry.byMagic))
+	for _, net := range registry.byMagic {
+		nets = append(nets, net)
+	}
+	sort.Slice(nets, func(i, j int) bool {
+		return nets[i].Info.ProtocolMagic < nets[j].Info.ProtocolMagic
+	})
+	return nets
+}
+
+// FromNetworkId returns the default NetworkInfo for a network id found in an address header.
+// Addresses carry no protocol magic, so id 1 maps to mainnet and id 0 to the legacy testnet.
+func FromNetworkId(id byte) *NetworkInfo {
+	switch id {
+	case MainNet().NetworkId:
+		return MainNet()
+	case TestNet().NetworkId:
+		return TestNet()
+	}
+	for _, net := range Networks() {
+		if net.Info.NetworkId == id {
+			info := net.Info
+			return &info
+		}
+	}
+	return &NetworkInfo{NetworkId: id}
+}
+
+// FromPrefix returns the NetworkInfo for a network id found in a bech32 address with the prefix hrp.
+// Custom prefixes resolve to the registered network using them, other prefixes to FromNetworkId.
+func FromPrefix(hrp string, id byte) *NetworkInfo {
+	info := FromNetworkId(id)
+	if hrp == info.AddressPrefix() || hrp == info.StakePrefix() {
+		return info
+	}
+	for _, net := range Networks() {
+		if net.Info.NetworkId == id && (hrp == net.AddressPrefix || hrp == net.StakePrefix) {
+			info := net.Info
+			return &info
+		}
+	}
+	return info
+}
@@ -0,0 +1,194 @@
+package network
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNoEraHistory      = errors.New("no era history for network")
+	ErrInvalidEraHistory = errors.New("invalid era history")
+	ErrBeforeSystemStart = errors.New("time is before network system start")
+)
+
+// Era describes the slot parameters of a ledger era. Eras with equal slot parameters,
+// e.g. Shelley to Conway, can be collapsed into a single Era.
+type Era struct {
+	Name        string
+	StartEpoch  uint64
+	SlotLength  time.Duration
+	EpochLength uint64
+}
+
+type eraBound struct {
+	Era
+	startSlot uint64
+	startTime time.Time
+}
+
+// EraHistory translates between slots, epochs and wall clock time for a network.
+type EraHistory struct {
+	SystemStart time.Time
+	eras        []eraBound
+}
+
+// NewEraHistory returns a pointer to a new EraHistory given the network system start and its eras
+// in chronological order. The first era has to start at epoch 0, the last era is unbounded.
+func NewEraHistory(systemStart time.Time, eras ...Era) (*EraHistory, error) {
+	if len(eras) == 0 || eras[0].StartEpoch != 0 {
+		return nil, ErrInvalidEraHistory
+	}
+
+	h := &EraHistory{SystemStart: systemStart.UTC()}
+	slot, start := uint64(0), h.SystemStart
+	for i, era := range eras {
+		if era.SlotLength <= 0 || era.EpochLength == 0 {
+			return nil, ErrInvalidEraHistory
+		}
+		if i > 0 {
+			prev := h.eras[i-1]
+			if era.StartEpoch < prev.StartEpoch {
+				return nil, ErrInvalidEraHistory
+			}
+			slots := (era.StartEpoch - prev.StartEpoch) * prev.EpochLength
+			slot += slots
+			start = start.Add(time.Duration(slots) * prev.SlotLength)
+		}
+		h.eras = append(h.eras, eraBound{Era: era, startSlot: slot, startTime: start})
+	}
+	return h, nil
+}
+
+// NewEraHistoryFromGenesis returns a pointer to a new EraHistory built from the byron and shelley
+// genesis parameters of a network. Byron epochs are 10k slots long where k is the security parameter,
+// shelleyStartEpoch is the first epoch of the shelley hard fork (0 when the network starts in shelley).
+func NewEraHistoryFromGenesis(
+	systemStart time.Time,
+	byronSlotLength time.Duration,
+	byronSecurityParam uint64,
+	shelleyStartEpoch uint64,
+	shelleySlotLength time.Duration,
+	shelleyEpochLength uint64,
+) (*EraHistory, error) {
+	shelley := Era{
+		Name:        "shelley",
+		StartEpoch:  shelleyStartEpoch,
+		SlotLength:  shelleySlotLength,
+		EpochLength: shelleyEpochLength,
+	}
+	if shelleyStartEpoch == 0 {
+		return NewEraHistory(systemStart, shelley)
+	}
+	byron := Era{
+		Name:        "byron",
+		SlotLength:  byronSlotLength,
+		EpochLength: byronSecurityParam * 10,
+	}
+	return NewEraHistory(systemStart, byron, shelley)
+}
+
+// Eras returns the eras of the history in chronological order.
+func (h *EraHistory) Eras() []Era {
+	eras := make([]Era, len(h.eras))
+	for i, era := range h.eras {
+		eras[i] = era.Era
+	}
+	return eras
+}
+
+func (h *EraHistory) eraForSlot(slot uint64) eraBound {
+	era := h.eras[0]
+	for _, e := range h.eras[1:] {
+		if slot < e.startSlot {
+			break
+		}
+		era = e
+	}
+	return era
+}
+
+func (h *EraHistory) eraForEpoch(epoch uint64) eraBound {
+	era := h.eras[0]
+	for _, e := range h.eras[1:] {
+		if epoch < e.StartEpoch {
+			break
+		}
+		era = e
+	}
+	return era
+}
+
+// SlotToTime returns the UTC time at which the slot begins.
+func (h *EraHistory) SlotToTime(slot uint64) time.Time {
+	era := h.eraForSlot(slot)
+	return era.startTime.Add(time.Duration(slot-era.startSlot) * era.SlotLength)
+}
+
+// TimeToSlot returns the slot containing the given time.
+func (h *EraHistory) TimeToSlot(t time.Time) (uint64, error) {
+	if t.Before(h.SystemStart) {
+		return 0, ErrBeforeSystemStart
+	}
+	era := h.eras[0]
+	for _, e := range h.eras[1:] {
+		if t.Before(e.startTime) {
+			break
+		}
+		era = e
+	}
+	return era.startSlot + uint64(t.Sub(era.startTime)/era.SlotLength), nil
+}
+
+// SlotIn returns the slot reached after the duration elapsed from now, e.g. for a transaction time to live.
+func (h *EraHistory) SlotIn(d time.Duration) (uint64, error) {
+	return h.TimeToSlot(time.Now().Add(d))
+}
+
+// SlotToEpoch returns the epoch of the slot and the index of the slot within the epoch.
+func (h *EraHistory) SlotToEpoch(slot uint64) (epoch, slotInEpoch uint64) {
+	era := h.eraForSlot(slot)
+	rel := slot - era.startSlot
+	return era.StartEpoch + rel/era.EpochLength, rel % era.EpochLength
+}
+
+// EpochToSlot returns the first slot of the epoch.
+func (h *EraHistory) EpochToSlot(epoch uint64) uint64 {
+	era := h.eraForEpoch(epoch)
+	return era.startSlot + (epoch-era.StartEpoch)*era.EpochLength
+}
+
+// EpochToTime returns the UTC time at which the epoch begins.
+func (h *EraHistory) EpochToTime(epoch uint64) time.Time {
+	return h.SlotToTime(h.EpochToSlot(epoch))
+}
+
+// TimeToEpoch returns the epoch containing the given time.
+func (h *EraHistory) TimeToEpoch(t time.Time) (uint64, error) {
+	slot, err := h.TimeToSlot(t)
+	if err != nil {
+		return 0, err
+	}
+	epoch, _ := h.SlotToEpoch(slot)
+	return epoch, nil
+}
+
+// EraHistory returns the era history registered for the network.
+func (n NetworkInfo) EraHistory() (*EraHistory, error) {
+	net, err := ByMagic(n.ProtocolMagic)
+	if err != nil || net.EraHistory == nil {
+		return nil, ErrNoEraHistory
+	}
+	return net.EraHistory, nil
+}
+
+func mustEraHistoryFromGenesis(systemStart string, byronEpochs, shelleyEpochLength uint64) *EraHistory {
+	start, err := time.Parse(time.RFC3339, systemStart)
+	if err != nil {
+		panic(err)
+	}
+	h, err := NewEraHistoryFromGenesis(start, 20*time.Second, 2160, byronEpochs, time.Second, shelleyEpochLength)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
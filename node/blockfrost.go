@@ -16,6 +16,27 @@ import (
 	"github.com/fivebinaries/go-cardano-serialization/tx"
 )
 
+// blockfrostServers maps protocol magics of the networks hosted by blockfrost to their API servers.
+var blockfrostServers = map[uint32]string{
+	network.MainNetMagic:   blockfrost.CardanoMainNet,
+	network.TestNetMagic:   blockfrost.CardanoTestNet,
+	network.PreProdMagic:   "https://cardano-preprod.blockfrost.io/api/v0",
+	network.PreviewMagic:   "https://cardano-preview.blockfrost.io/api/v0",
+	network.SanchoNetMagic: "https://cardano-sanchonet.blockfrost.io/api/v0",
+}
+
+// blockfrostServer returns the API server for the network, defaulting to mainnet or the legacy
+// testnet by network id when blockfrost does not host the network.
+func blockfrostServer(net *network.NetworkInfo) string {
+	if serverUrl, ok := blockfrostServers[net.ProtocolMagic]; ok {
+		return serverUrl
+	}
+	if net.NetworkId == network.MainNet().NetworkId {
+		return blockfrost.CardanoMainNet
+	}
+	return blockfrost.CardanoTestNet
+}
+
 type blockfrostNode struct {
 	network   *network.NetworkInfo
 	client    blockfrost.APIClient
@@ -23,13 +44,17 @@ type blockfrostNode struct {
 }
 
 func (b *blockfrostNode) getNetwork() (serverUrl string) {
-	if b.network.NetworkId == 0 {
-		serverUrl = blockfrost.CardanoTestNet
-	} else {
-		serverUrl = blockfrost.CardanoMainNet
-	}
+	return blockfrostServer(b.network)
+}
 
-	return
+// isNotFound reports whether the API returned not found, as it does for addresses which were never used.
+func isNotFound(err error) bool {
+	var apiErr *blockfrost.APIError
+	if errors.As(err, &apiErr) {
+		_, ok := apiErr.Response.(blockfrost.NotFound)
+		return ok
+	}
+	return false
 }
 
 // UTXOs queries the network for Unspent Transaction Outputs belonging to an address.
@@ -40,6 +65,9 @@ func (b *blockfrostNode) UTXOs(addr address.Address) (txIs []tx.TxInput, err err
 		blockfrost.APIQueryParams{},
 	)
 	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
 		return
 	}
 
@@ -62,6 +90,22 @@ func (b *blockfrostNode) UTXOs(addr address.Address) (txIs []tx.TxInput, err err
 	return
 }
 
+// HasTransactions queries the network for the first transaction of the address.
+func (b *blockfrostNode) HasTransactions(addr address.Address) (bool, error) {
+	txs, err := b.client.AddressTransactions(
+		context.TODO(),
+		addr.String(),
+		blockfrost.APIQueryParams{Count: 1},
+	)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return len(txs) > 0, nil
+}
+
 // ProtocolParameters queries the protocol parameters of the network.
 func (b *blockfrostNode) ProtocolParameters() (p protocol.Protocol, err error) {
 	params, err := b.client.LatestEpochParameters(context.TODO())
@@ -74,15 +118,27 @@ func (b *blockfrostNode) ProtocolParameters() (p protocol.Protocol, err error) {
 		return
 	}
 
+	keyDeposit, err := strconv.Atoi(params.KeyDeposit)
+	if err != nil {
+		return
+	}
+
+	poolDeposit, err := strconv.Atoi(params.PoolDeposit)
+	if err != nil {
+		return
+	}
+
 	return protocol.Protocol{
 		TxFeePerByte: uint(params.MinFeeA),
 		TxFeeFixed:   uint(params.MinFeeB),
 		MaxTxSize:    uint(params.MaxTxSize),
 		ProtocolVersion: protocol.ProtocolVersion{
-			uint8(params.ProtocolMajorVer),
-			uint8(params.ProtocolMinorVer),
+			Major: uint8(params.ProtocolMajorVer),
+			Minor: uint8(params.ProtocolMinorVer),
 		},
 		MinUTXOValue: uint(minU),
+		KeyDeposit:   uint(keyDeposit),
+		PoolDeposit:  uint(poolDeposit),
 	}, nil
 }
 
@@ -126,17 +182,10 @@ func (b *blockfrostNode) SubmitTx(txFinal tx.Tx) (txHash string, err error) {
 
 // NewBlockfrostClient returns a wrapper for the blockfrost API/SDK with Node interface
 func NewBlockfrostClient(projectId string, network *network.NetworkInfo) Node {
-	var serverUrl string
-	if network.NetworkId == 0 {
-		serverUrl = blockfrost.CardanoTestNet
-	} else {
-		serverUrl = blockfrost.CardanoMainNet
-	}
-
 	client := blockfrost.NewAPIClient(
 		blockfrost.APIClientOptions{
 			ProjectID: projectId,
-			Server:    serverUrl,
+			Server:    blockfrostServer(network),
 		},
 	)
 
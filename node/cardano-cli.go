@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/envelope"
 	"github.com/fivebinaries/go-cardano-serialization/network"
 	"github.com/fivebinaries/go-cardano-serialization/protocol"
 	"github.com/fivebinaries/go-cardano-serialization/tx"
@@ -23,15 +24,13 @@ type cardanoCli struct {
 
 func (cli *cardanoCli) execCommand(args ...string) (data []byte, err error) {
 	buf := &bytes.Buffer{}
-	var cmdSuffix string
 
-	if cli.network.NetworkId == 0 {
-		cmdSuffix = "--mainnet"
+	if cli.network.IsMainNet() {
+		args = append(args, "--mainnet")
 	} else {
-		cmdSuffix = fmt.Sprintf("--testnet-magic %d", cli.network.ProtocolMagic)
+		args = append(args, "--testnet-magic", strconv.FormatUint(uint64(cli.network.ProtocolMagic), 10))
 	}
 
-	args = append(args, cmdSuffix)
 	cmd := exec.Command(cli.cliPath, args...)
 	cmd.Stdout = buf
 	cmd.Stderr = os.Stderr
@@ -102,21 +101,11 @@ func (cli *cardanoCli) QueryTip() (tip NetworkTip, err error) {
 }
 
 func (cli *cardanoCli) SubmitTx(txFinal tx.Tx) (txHash string, err error) {
-	type sb struct {
-		TxType      string `json:"type"`
-		Description string `json:"description"`
-		CborHex     string `json:"cborHex"`
-	}
-
-	txHex, err := txFinal.Hex()
+	outTx, err := envelope.NewTx(&txFinal, envelope.ConwayEra)
 	if err != nil {
 		return
 	}
 
-	outTx := sb{
-		CborHex: txHex,
-	}
-
 	tmpFile, err := ioutil.TempFile(os.TempDir(), "gada-tx-")
 	if err != nil {
 		return
@@ -22,3 +22,10 @@ type Node interface {
 	// Using `query tip` on cardano-cli requires a synced local node
 	QueryTip() (NetworkTip, error)
 }
+
+// AddressHistory is implemented by nodes indexing the transactions of addresses, e.g. blockfrost.
+type AddressHistory interface {
+	// HasTransactions reports whether any transaction paid to or spent from the address,
+	// including addresses whose outputs were all spent since.
+	HasTransactions(address.Address) (bool, error)
+}
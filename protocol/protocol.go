@@ -27,6 +27,18 @@ type Protocol struct {
 
 	// Minimum UTXO Value
 	MinUTXOValue uint `json:"minUTxOValue"`
+
+	// The deposit (in lovelace) required to register a stake key.
+	KeyDeposit uint `json:"stakeAddressDeposit"`
+
+	// The deposit (in lovelace) required to register a stake pool.
+	PoolDeposit uint `json:"stakePoolDeposit"`
+
+	// The deposit (in lovelace) required to register a delegate representative.
+	DRepDeposit uint `json:"dRepDeposit"`
+
+	// The deposit (in lovelace) required to submit a governance action.
+	GovActionDeposit uint `json:"govActionDeposit"`
 }
 
 // LOadProtocol returns a pointer to a unmarshalled Protocol given a file path of a
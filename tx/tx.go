@@ -1,7 +1,9 @@
 package tx
 
 import (
+	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 
 	"github.com/fivebinaries/go-cardano-serialization/fees"
@@ -9,11 +11,19 @@ import (
 	"golang.org/x/crypto/blake2b"
 )
 
+var (
+	ErrInvalidTx = errors.New("invalid transaction")
+)
+
 type Tx struct {
-	_        struct{} `cbor:",toarray"`
-	Body     *TxBody
-	Witness  *Witness
-	Valid    bool
+	Body          *TxBody
+	Witness       *Witness
+	Valid         bool
+	AuxiliaryData *AuxiliaryData
+
+	// Metadata is encoded as the auxiliary data of the transaction if AuxiliaryData is nil.
+	//
+	// Deprecated: use AuxiliaryData, e.g. NewAuxiliaryData(metadata, nil, nil).
 	Metadata interface{}
 }
 
@@ -26,6 +36,68 @@ func NewTx() *Tx {
 	}
 }
 
+// MarshalCBOR returns the cbor encoding of the transaction.
+func (t Tx) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{t.Body, t.Witness, t.Valid, t.auxiliaryData()})
+}
+
+// auxiliaryData returns the auxiliary data of the transaction, the deprecated Metadata if AuxiliaryData is nil.
+func (t *Tx) auxiliaryData() interface{} {
+	if t.AuxiliaryData != nil {
+		return t.AuxiliaryData
+	}
+	return t.Metadata
+}
+
+// UnmarshalCBOR deserializes a cbor encoded transaction of the shelley or a later era, e.g. the content of a
+// `Tx ConwayEra` envelope of cardano-cli. The body and auxiliary data keep their encoding, so the hash of the
+// decoded transaction is the hash its witnesses signed.
+func (t *Tx) UnmarshalCBOR(data []byte) error {
+	body, witness, valid, aux, err := splitTx(data)
+	if err != nil {
+		return err
+	}
+
+	decoded := Tx{Valid: true}
+	if valid != nil {
+		if err := cbor.Unmarshal(valid, &decoded.Valid); err != nil {
+			return err
+		}
+	}
+	if err := cbor.Unmarshal(body, &decoded.Body); err != nil {
+		return err
+	}
+	if err := cbor.Unmarshal(witness, &decoded.Witness); err != nil {
+		return err
+	}
+	if err := cbor.Unmarshal(aux, &decoded.AuxiliaryData); err != nil {
+		return err
+	}
+	if decoded.Body == nil || decoded.Witness == nil {
+		return ErrInvalidTx
+	}
+
+	*t = decoded
+	return nil
+}
+
+// splitTx returns the encoded elements of a cbor encoded transaction. Shelley transactions are
+// [body, witnesses, auxiliary data], later eras insert the validity flag, which is nil for shelley.
+func splitTx(data []byte) (body, witness, valid, aux cbor.RawMessage, err error) {
+	var raw []cbor.RawMessage
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	switch len(raw) {
+	case 3:
+		return raw[0], raw[1], nil, raw[2], nil
+	case 4:
+		return raw[0], raw[1], raw[2], raw[3], nil
+	}
+	return nil, nil, nil, nil, ErrInvalidTx
+}
+
 // Bytes returns a slice of cbor marshalled bytes
 func (t *Tx) Bytes() ([]byte, error) {
 	if err := t.CalculateAuxiliaryDataHash(); err != nil {
@@ -59,6 +131,28 @@ func (t *Tx) Hash() ([32]byte, error) {
 	return txHash, nil
 }
 
+// VerifyWitnesses checks the vkey witnesses against the hash of the transaction body and returns the witnesses
+// whose signatures are invalid, e.g. to validate a partially signed transaction before adding more witnesses.
+// The body of a decoded transaction is hashed in its original encoding.
+func (t *Tx) VerifyWitnesses() ([]*VKeyWitness, error) {
+	txHash, err := t.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Witness == nil {
+		return nil, nil
+	}
+
+	var invalid []*VKeyWitness
+	for _, witness := range t.Witness.Keys {
+		if !witness.Verify(txHash[:]) {
+			invalid = append(invalid, witness)
+		}
+	}
+	return invalid, nil
+}
+
 // Fee returns the fee(in lovelaces) required by the transaction from the linear formula
 // fee = txFeeFixed + txFeePerByte*tx_len_in_bytes
 func (t *Tx) Fee(lfee *fees.LinearFee) (uint, error) {
@@ -78,16 +172,32 @@ func (t *Tx) Fee(lfee *fees.LinearFee) (uint, error) {
 // SetFee sets the fee
 func (t *Tx) SetFee(fee uint) {
 	t.Body.Fee = uint64(fee)
+	t.Body.ResetEncoding()
 }
 
+// CalculateAuxiliaryDataHash sets the auxiliary data hash of the transaction body to the hash of the auxiliary data.
 func (t *Tx) CalculateAuxiliaryDataHash() error {
-	if t.Metadata != nil {
-		mdBytes, err := cbor.Marshal(&t.Metadata)
+	var auxHash [32]byte
+	switch {
+	case t.AuxiliaryData != nil:
+		hash, err := t.AuxiliaryData.Hash()
+		if err != nil {
+			return fmt.Errorf("cannot serialize auxiliary data: %w", err)
+		}
+		auxHash = hash
+	case t.Metadata != nil:
+		mdBytes, err := cbor.Marshal(t.Metadata)
 		if err != nil {
 			return fmt.Errorf("cannot serialize metadata: %w", err)
 		}
-		auxHash := blake2b.Sum256(mdBytes)
+		auxHash = blake2b.Sum256(mdBytes)
+	default:
+		return nil
+	}
+
+	if !bytes.Equal(t.Body.AuxiliaryDataHash, auxHash[:]) {
 		t.Body.AuxiliaryDataHash = auxHash[:]
+		t.Body.ResetEncoding()
 	}
 	return nil
 }
@@ -95,6 +205,7 @@ func (t *Tx) CalculateAuxiliaryDataHash() error {
 // AddInputs adds the inputs to the transaction body
 func (t *Tx) AddInputs(inputs ...*TxInput) error {
 	t.Body.Inputs = append(t.Body.Inputs, inputs...)
+	t.Body.ResetEncoding()
 
 	return nil
 }
@@ -102,6 +213,7 @@ func (t *Tx) AddInputs(inputs ...*TxInput) error {
 // AddOutputs adds the outputs to the transaction body
 func (t *Tx) AddOutputs(outputs ...*TxOutput) error {
 	t.Body.Outputs = append(t.Body.Outputs, outputs...)
+	t.Body.ResetEncoding()
 
 	return nil
 }
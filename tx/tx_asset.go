@@ -0,0 +1,83 @@
+package tx
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fivebinaries/go-cardano-serialization/internal/bech32"
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	// AssetNameMaxLen is the maximum length in bytes of an asset name.
+	AssetNameMaxLen = 32
+
+	assetFingerprintPrefix = "asset"
+)
+
+var (
+	ErrInvalidAssetName        = errors.New("asset name is longer than 32 bytes")
+	ErrInvalidAssetFingerprint = errors.New("invalid asset fingerprint")
+)
+
+// AssetName is the name of a native token under its minting policy. It is an arbitrary byte string of up to 32 bytes.
+type AssetName []byte
+
+// NewAssetName returns a new AssetName of the bytes.
+func NewAssetName(name []byte) (AssetName, error) {
+	if len(name) > AssetNameMaxLen {
+		return nil, ErrInvalidAssetName
+	}
+	return AssetName(name), nil
+}
+
+// NewAssetNameFromHex returns a new AssetName of hex encoded bytes.
+func NewAssetNameFromHex(hexName string) (AssetName, error) {
+	name, err := hex.DecodeString(hexName)
+	if err != nil {
+		return nil, err
+	}
+	return NewAssetName(name)
+}
+
+// String returns the hex encoded asset name.
+func (a AssetName) String() string {
+	return hex.EncodeToString(a)
+}
+
+// MarshalCBOR returns the cbor encoding of the asset name as a byte string.
+func (a AssetName) MarshalCBOR() ([]byte, error) {
+	if len(a) > AssetNameMaxLen {
+		return nil, ErrInvalidAssetName
+	}
+	return cbor.Marshal([]byte(a))
+}
+
+// AssetFingerprint is the CIP-14 fingerprint of a native token, the Blake2b-160 hash of its policy id and asset name.
+type AssetFingerprint [crypto.Blake2b160Len]byte
+
+// NewAssetFingerprint returns the fingerprint of the asset with the name minted under the policy.
+func NewAssetFingerprint(policyId crypto.ScriptHash, name AssetName) AssetFingerprint {
+	return crypto.Blake2b160(append(policyId[:], name...))
+}
+
+// NewAssetFingerprintFromBech32 returns the fingerprint of a bech32 encoded `asset` string.
+func NewAssetFingerprintFromBech32(raw string) (AssetFingerprint, error) {
+	var fingerprint AssetFingerprint
+	hrp, data, err := bech32.Decode(raw)
+	if err != nil {
+		return fingerprint, err
+	}
+	if hrp != assetFingerprintPrefix || len(data) != len(fingerprint) {
+		return fingerprint, ErrInvalidAssetFingerprint
+	}
+	copy(fingerprint[:], data)
+	return fingerprint, nil
+}
+
+// String returns the bech32 encoded `asset` fingerprint.
+func (f AssetFingerprint) String() string {
+	str, _ := bech32.Encode(assetFingerprintPrefix, f[:])
+	return str
+}
@@ -0,0 +1,52 @@
+package tx_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fivebinaries/go-cardano-serialization/tx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetFingerprint(t *testing.T) {
+	// Test vectors from CIP-14
+	scenarios := []struct {
+		policyId    string
+		assetName   string
+		fingerprint string
+	}{
+		{"7eae28af2208be856f7a119668ae52a49b73725e326dc16579dcc373", "", "asset1rjklcrnsdzqp65wjgrg55sy9723kw09mlgvlc3"},
+		{"7eae28af2208be856f7a119668ae52a49b73725e326dc16579dcc37e", "", "asset1nl0puwxmhas8fawxp8nx4e2q3wekg969n2auw3"},
+		{"1e349c9bdea19fd6c147626a5260bc44b71635f398b67c59881df209", "", "asset1uyuxku60yqe57nusqzjx38aan3f2wq6s93f6ea"},
+		{"7eae28af2208be856f7a119668ae52a49b73725e326dc16579dcc373", "504154415445", "asset13n25uv0yaf5kus35fm2k86cqy60z58d9xmde92"},
+		{"1e349c9bdea19fd6c147626a5260bc44b71635f398b67c59881df209", "504154415445", "asset1hv4p5tv2a837mzqrst04d0dcptdjmluqvdx9k3"},
+		{"1e349c9bdea19fd6c147626a5260bc44b71635f398b67c59881df209", "7eae28af2208be856f7a119668ae52a49b73725e326dc16579dcc373", "asset1aqrdypg669jgazruv5ah07nuyqe0wxjhe2el6f"},
+		{"7eae28af2208be856f7a119668ae52a49b73725e326dc16579dcc373", "1e349c9bdea19fd6c147626a5260bc44b71635f398b67c59881df209", "asset17jd78wukhtrnmjh3fngzasxm8rck0l2r4hhyyt"},
+		{"7eae28af2208be856f7a119668ae52a49b73725e326dc16579dcc373", "0000000000000000000000000000000000000000000000000000000000000000", "asset1pkpwyknlvul7az0xx8czhl60pyel45rpje4z8w"},
+	}
+
+	for _, sc := range scenarios {
+		t.Run(sc.fingerprint, func(t *testing.T) {
+			policyBytes, _ := hex.DecodeString(sc.policyId)
+			policyId, err := crypto.ScriptHashFromBytes(policyBytes)
+			assert.NoError(t, err)
+			name, err := tx.NewAssetNameFromHex(sc.assetName)
+			assert.NoError(t, err)
+
+			fingerprint := tx.NewAssetFingerprint(policyId, name)
+			assert.Equal(t, sc.fingerprint, fingerprint.String())
+
+			decoded, err := tx.NewAssetFingerprintFromBech32(sc.fingerprint)
+			assert.NoError(t, err)
+			assert.Equal(t, fingerprint, decoded)
+		})
+	}
+
+	_, err := tx.NewAssetName(bytes.Repeat([]byte{0x01}, 33))
+	assert.ErrorIs(t, err, tx.ErrInvalidAssetName)
+
+	_, err = tx.NewAssetFingerprintFromBech32("pool1pu5jlj4q9w9jlxeu370a3c9myx47md5j5m2str0naunn2q3lkdy")
+	assert.ErrorIs(t, err, tx.ErrInvalidAssetFingerprint)
+}
@@ -0,0 +1,224 @@
+package tx
+
+import (
+	"errors"
+
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// auxiliaryDataTag is the cbor tag of the Alonzo auxiliary data map.
+const auxiliaryDataTag = 259
+
+var (
+	ErrInvalidAuxiliaryData = errors.New("invalid auxiliary data")
+)
+
+// AuxiliaryDataFormat is the cbor encoding of the auxiliary data.
+type AuxiliaryDataFormat uint8
+
+const (
+	// ShelleyAuxiliaryData is the bare metadata map and cannot carry scripts.
+	ShelleyAuxiliaryData AuxiliaryDataFormat = iota
+	// ShelleyMAAuxiliaryData is the `[metadata, [native scripts]]` array.
+	ShelleyMAAuxiliaryData
+	// AlonzoAuxiliaryData is the map tagged 259 which also carries Plutus scripts.
+	AlonzoAuxiliaryData
+)
+
+// AuxiliaryData contains the metadata and scripts attached to a transaction.
+//
+// Decoded auxiliary data keeps its original encoding, which is encoded and hashed instead of the fields so
+// the auxiliary data hash of the transaction body stays valid. Changes made to the fields take effect after
+// ResetEncoding.
+type AuxiliaryData struct {
+	Format        AuxiliaryDataFormat
+	Metadata      TransactionMetadata
+	NativeScripts []*NativeScript
+	PlutusScripts []*PlutusScript
+
+	raw []byte
+}
+
+// NewAuxiliaryData returns a pointer to new AuxiliaryData in the most compact format able to hold the scripts.
+func NewAuxiliaryData(metadata TransactionMetadata, nativeScripts []*NativeScript, plutusScripts []*PlutusScript) *AuxiliaryData {
+	format := ShelleyAuxiliaryData
+	if len(plutusScripts) > 0 {
+		format = AlonzoAuxiliaryData
+	} else if len(nativeScripts) > 0 {
+		format = ShelleyMAAuxiliaryData
+	}
+
+	return &AuxiliaryData{
+		Format:        format,
+		Metadata:      metadata,
+		NativeScripts: nativeScripts,
+		PlutusScripts: plutusScripts,
+	}
+}
+
+// NewAuxiliaryDataFromTxBytes returns a pointer to the AuxiliaryData of a cbor encoded transaction or nil
+// if the transaction has none.
+func NewAuxiliaryDataFromTxBytes(data []byte) (*AuxiliaryData, error) {
+	_, _, _, raw, err := splitTx(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var aux *AuxiliaryData
+	if err := cbor.Unmarshal(raw, &aux); err != nil {
+		return nil, err
+	}
+	return aux, nil
+}
+
+// Validate checks that the metadata is valid and the scripts fit the format.
+func (a *AuxiliaryData) Validate() error {
+	if err := a.Metadata.Validate(); err != nil {
+		return err
+	}
+
+	switch a.Format {
+	case ShelleyAuxiliaryData:
+		if len(a.NativeScripts) > 0 || len(a.PlutusScripts) > 0 {
+			return errors.New("shelley auxiliary data cannot carry scripts")
+		}
+	case ShelleyMAAuxiliaryData:
+		if len(a.PlutusScripts) > 0 {
+			return errors.New("shelley-ma auxiliary data cannot carry plutus scripts")
+		}
+	case AlonzoAuxiliaryData:
+		for _, script := range a.PlutusScripts {
+			if script.Version > PlutusV3 {
+				return ErrInvalidPlutusScript
+			}
+		}
+	default:
+		return ErrInvalidAuxiliaryData
+	}
+
+	return nil
+}
+
+// MarshalCBOR returns the cbor encoding of the auxiliary data in its format, the original encoding for decoded
+// auxiliary data.
+func (a *AuxiliaryData) MarshalCBOR() ([]byte, error) {
+	if a.raw != nil {
+		return a.raw, nil
+	}
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	metadata := a.Metadata
+	if metadata == nil {
+		metadata = TransactionMetadata{}
+	}
+
+	switch a.Format {
+	case ShelleyAuxiliaryData:
+		return metadata.MarshalCBOR()
+	case ShelleyMAAuxiliaryData:
+		scripts := a.NativeScripts
+		if scripts == nil {
+			scripts = []*NativeScript{}
+		}
+		return cbor.Marshal([]interface{}{metadata, scripts})
+	}
+
+	type alonzoAuxiliaryData struct {
+		Metadata        *TransactionMetadata `cbor:"0,keyasint,omitempty"`
+		NativeScripts   []*NativeScript      `cbor:"1,keyasint,omitempty"`
+		PlutusV1Scripts [][]byte             `cbor:"2,keyasint,omitempty"`
+		PlutusV2Scripts [][]byte             `cbor:"3,keyasint,omitempty"`
+		PlutusV3Scripts [][]byte             `cbor:"4,keyasint,omitempty"`
+	}
+	aux := alonzoAuxiliaryData{NativeScripts: a.NativeScripts}
+	if len(a.Metadata) > 0 {
+		aux.Metadata = &a.Metadata
+	}
+	for _, script := range a.PlutusScripts {
+		switch script.Version {
+		case PlutusV1:
+			aux.PlutusV1Scripts = append(aux.PlutusV1Scripts, script.Script)
+		case PlutusV2:
+			aux.PlutusV2Scripts = append(aux.PlutusV2Scripts, script.Script)
+		case PlutusV3:
+			aux.PlutusV3Scripts = append(aux.PlutusV3Scripts, script.Script)
+		}
+	}
+	return cbor.Marshal(cbor.Tag{Number: auxiliaryDataTag, Content: aux})
+}
+
+// UnmarshalCBOR deserializes auxiliary data in any of the Shelley, Shelley-MA and Alonzo formats.
+func (a *AuxiliaryData) UnmarshalCBOR(data []byte) error {
+	if len(data) == 0 {
+		return ErrInvalidAuxiliaryData
+	}
+
+	aux := AuxiliaryData{raw: append([]byte{}, data...)}
+	switch data[0] >> 5 {
+	case 5:
+		aux.Format = ShelleyAuxiliaryData
+		if err := cbor.Unmarshal(data, &aux.Metadata); err != nil {
+			return err
+		}
+	case 4:
+		aux.Format = ShelleyMAAuxiliaryData
+		var raw struct {
+			_             struct{} `cbor:",toarray"`
+			Metadata      TransactionMetadata
+			NativeScripts []*NativeScript
+		}
+		if err := cbor.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		aux.Metadata = raw.Metadata
+		aux.NativeScripts = raw.NativeScripts
+	case 6:
+		aux.Format = AlonzoAuxiliaryData
+		var tag cbor.RawTag
+		if err := cbor.Unmarshal(data, &tag); err != nil {
+			return err
+		}
+		if tag.Number != auxiliaryDataTag {
+			return ErrInvalidAuxiliaryData
+		}
+		var raw struct {
+			Metadata        TransactionMetadata `cbor:"0,keyasint"`
+			NativeScripts   []*NativeScript     `cbor:"1,keyasint"`
+			PlutusV1Scripts [][]byte            `cbor:"2,keyasint"`
+			PlutusV2Scripts [][]byte            `cbor:"3,keyasint"`
+			PlutusV3Scripts [][]byte            `cbor:"4,keyasint"`
+		}
+		if err := cbor.Unmarshal(tag.Content, &raw); err != nil {
+			return err
+		}
+		aux.Metadata = raw.Metadata
+		aux.NativeScripts = raw.NativeScripts
+		for version, scripts := range [][][]byte{raw.PlutusV1Scripts, raw.PlutusV2Scripts, raw.PlutusV3Scripts} {
+			for _, script := range scripts {
+				aux.PlutusScripts = append(aux.PlutusScripts, &PlutusScript{Version: uint(version), Script: script})
+			}
+		}
+	default:
+		return ErrInvalidAuxiliaryData
+	}
+
+	*a = aux
+	return nil
+}
+
+// ResetEncoding discards the original encoding of decoded auxiliary data, so changes made to its fields are encoded.
+func (a *AuxiliaryData) ResetEncoding() {
+	a.raw = nil
+}
+
+// Hash returns the blake2b256 hash of the encoded auxiliary data referenced by the transaction body.
+func (a *AuxiliaryData) Hash() (crypto.MetadataHash, error) {
+	data, err := a.MarshalCBOR()
+	if err != nil {
+		return crypto.MetadataHash{}, err
+	}
+	return crypto.MetadataHash(crypto.Blake2b256(data)), nil
+}
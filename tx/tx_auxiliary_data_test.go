@@ -0,0 +1,172 @@
+package tx_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fivebinaries/go-cardano-serialization/network"
+	"github.com/fivebinaries/go-cardano-serialization/protocol"
+	"github.com/fivebinaries/go-cardano-serialization/tx"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestScriptHash(t *testing.T) {
+	keyHash, _ := crypto.Ed25519KeyHashFromBytes(bytes.Repeat([]byte{0x02}, 28))
+	hash, err := tx.NewPubKeyNativeScript(keyHash).Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, "b89844e76f2d317d85fccac60fa385d8d0e8204737ded45c995ce2e3", hex.EncodeToString(hash[:]))
+
+	plutus, err := tx.NewPlutusScript(tx.PlutusV1, []byte{0x01, 0x02, 0x03})
+	assert.NoError(t, err)
+	hash, err = plutus.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, "bc8f82996834417a91ad5f5bbf06a35e1fdbfe42f21ff91485f443f1", hex.EncodeToString(hash[:]))
+
+	script := tx.NewAllNativeScript(
+		tx.NewNOfKNativeScript(1, tx.NewPubKeyNativeScript(keyHash)),
+		tx.NewInvalidBeforeNativeScript(100),
+		tx.NewInvalidHereafterNativeScript(200),
+	)
+	data, err := script.MarshalCBOR()
+	assert.NoError(t, err)
+	assert.Equal(t, "820183830301818200581c"+hex.EncodeToString(keyHash[:])+"82041864820518c8", hex.EncodeToString(data))
+
+	var decoded tx.NativeScript
+	assert.NoError(t, cbor.Unmarshal(data, &decoded))
+	assert.Equal(t, script, &decoded)
+}
+
+func TestAuxiliaryDataFormats(t *testing.T) {
+	keyHash, _ := crypto.Ed25519KeyHashFromBytes(bytes.Repeat([]byte{0x02}, 28))
+	metadata := tx.TransactionMetadata{674: tx.NewTextMetadatum("hi")}
+	nativeScripts := []*tx.NativeScript{tx.NewPubKeyNativeScript(keyHash)}
+	plutus, _ := tx.NewPlutusScript(tx.PlutusV1, []byte{0x01, 0x02, 0x03})
+
+	scenarios := []struct {
+		description string
+		aux         *tx.AuxiliaryData
+		cborHex     string
+	}{
+		{
+			description: "shelley metadata map",
+			aux:         tx.NewAuxiliaryData(metadata, nil, nil),
+			cborHex:     "a11902a2626869",
+		},
+		{
+			description: "shelley-ma array with native scripts",
+			aux:         tx.NewAuxiliaryData(metadata, nativeScripts, nil),
+			cborHex:     "82a11902a2626869818200581c" + hex.EncodeToString(keyHash[:]),
+		},
+		{
+			description: "alonzo tagged map with plutus scripts",
+			aux:         tx.NewAuxiliaryData(metadata, nil, []*tx.PlutusScript{plutus}),
+			cborHex:     "d90103a200a11902a2626869028143010203",
+		},
+	}
+
+	for _, sc := range scenarios {
+		t.Run(sc.description, func(t *testing.T) {
+			data, err := sc.aux.MarshalCBOR()
+			assert.NoError(t, err)
+			assert.Equal(t, sc.cborHex, hex.EncodeToString(data))
+
+			var decoded tx.AuxiliaryData
+			assert.NoError(t, cbor.Unmarshal(data, &decoded))
+			encoded, err := decoded.MarshalCBOR()
+			assert.NoError(t, err)
+			assert.Equal(t, data, encoded)
+			decoded.ResetEncoding()
+			assert.Equal(t, sc.aux, &decoded)
+		})
+	}
+
+	invalid := &tx.AuxiliaryData{Format: tx.ShelleyAuxiliaryData, NativeScripts: nativeScripts}
+	_, err := invalid.MarshalCBOR()
+	assert.Error(t, err)
+}
+
+func TestTxBuilderAuxiliaryData(t *testing.T) {
+	addr, utxoPrv, err := generateBaseAddress(network.TestNet())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	builder := tx.NewTxBuilder(protocol.Protocol{TxFeePerByte: 44, TxFeeFixed: 155381}, []bip32.XPrv{utxoPrv})
+	builder.AddInputs(tx.NewTxInput("fcbc18c64cdf133f33dd319c5105dc7c4972f2d646ae276fbd00cf7f39f8c380", 0, 1000000000))
+	assert.NoError(t, builder.SetMetadata(tx.TransactionMetadata{674: tx.NewTextMetadatum("hi")}))
+	builder.AddChangeIfNeeded(addr)
+
+	txFinal, err := builder.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "965d520c4df4f8566c5b5adc8f7b0b39f8668c779a0013cf00e147c5f86e17e3", hex.EncodeToString(txFinal.Body.AuxiliaryDataHash))
+
+	data, err := txFinal.Bytes()
+	assert.NoError(t, err)
+	aux, err := tx.NewAuxiliaryDataFromTxBytes(data)
+	assert.NoError(t, err)
+	aux.ResetEncoding()
+	assert.Equal(t, txFinal.AuxiliaryData, aux)
+
+	// Shelley transactions carry the auxiliary data as third element
+	aux, err = tx.NewAuxiliaryDataFromTxBytes([]byte{0x83, 0xa0, 0xa0, 0xf6})
+	assert.NoError(t, err)
+	assert.Nil(t, aux)
+}
+
+func TestDecodedAuxiliaryData(t *testing.T) {
+	// Metadata with its keys out of the canonical order, as other tools may encode it.
+	auxBytes, _ := hex.DecodeString("a21902a36162" + "1902a26161")
+	auxHash := blake2b.Sum256(auxBytes)
+
+	txFinal := tx.NewTx()
+	txFinal.AddInputs(tx.NewTxInput("fcbc18c64cdf133f33dd319c5105dc7c4972f2d646ae276fbd00cf7f39f8c380", 0, 1000000000))
+	txFinal.SetFee(200000)
+	txFinal.Body.AuxiliaryDataHash = auxHash[:]
+	bodyBytes, err := cbor.Marshal(txFinal.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := cbor.Marshal([]cbor.RawMessage{bodyBytes, {0xa0}, {0xf5}, auxBytes})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := &tx.Tx{}
+	assert.NoError(t, cbor.Unmarshal(data, decoded))
+	assert.Equal(t, tx.NewTextMetadatum("a"), decoded.AuxiliaryData.Metadata[674])
+	hash, err := decoded.AuxiliaryData.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, auxHash[:], hash[:])
+
+	assert.NoError(t, decoded.CalculateAuxiliaryDataHash())
+	txHash, err := decoded.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, blake2b.Sum256(bodyBytes), txHash)
+	encoded, err := decoded.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, data, encoded)
+
+	// Changing the auxiliary data encodes it again.
+	decoded.AuxiliaryData.ResetEncoding()
+	assert.NoError(t, decoded.CalculateAuxiliaryDataHash())
+	assert.NotEqual(t, auxHash[:], decoded.Body.AuxiliaryDataHash)
+}
+
+func TestDeprecatedMetadata(t *testing.T) {
+	txFinal := tx.NewTx()
+	txFinal.AddInputs(tx.NewTxInput("fcbc18c64cdf133f33dd319c5105dc7c4972f2d646ae276fbd00cf7f39f8c380", 0, 1000000000))
+	txFinal.Metadata = map[uint]string{674: "hi"}
+	assert.NoError(t, txFinal.CalculateAuxiliaryDataHash())
+	assert.Equal(t, "965d520c4df4f8566c5b5adc8f7b0b39f8668c779a0013cf00e147c5f86e17e3", hex.EncodeToString(txFinal.Body.AuxiliaryDataHash))
+
+	data, err := txFinal.Bytes()
+	assert.NoError(t, err)
+	aux, err := tx.NewAuxiliaryDataFromTxBytes(data)
+	assert.NoError(t, err)
+	assert.Equal(t, tx.NewTextMetadatum("hi"), aux.Metadata[674])
+}
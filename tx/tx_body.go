@@ -7,12 +7,26 @@ import (
 )
 
 // TxBody contains the inputs, outputs, fee and titme to live for the transaction.
+//
+// A decoded body keeps its original encoding, which is encoded and hashed instead of the fields so the
+// witnesses of a transaction built elsewhere stay valid. The Tx setters discard it, changes made to the
+// fields directly take effect after ResetEncoding.
 type TxBody struct {
-	Inputs            []*TxInput  `cbor:"0,keyasint"`
-	Outputs           []*TxOutput `cbor:"1,keyasint"`
-	Fee               uint64      `cbor:"2,keyasint"`
-	TTL               uint32      `cbor:"3,keyasint,omitempty"`
-	AuxiliaryDataHash []byte      `cbor:"7,keyasint,omitempty"`
+	Inputs            []*TxInput    `cbor:"0,keyasint"`
+	Outputs           []*TxOutput   `cbor:"1,keyasint"`
+	Fee               uint64        `cbor:"2,keyasint"`
+	TTL               uint32        `cbor:"3,keyasint,omitempty"`
+	Certificates      []Certificate `cbor:"4,keyasint,omitempty"`
+	AuxiliaryDataHash []byte        `cbor:"7,keyasint,omitempty"`
+
+	VotingProcedures     *VotingProcedures    `cbor:"19,keyasint,omitempty"`
+	ProposalProcedures   []*ProposalProcedure `cbor:"20,keyasint,omitempty"`
+	CurrentTreasuryValue *uint64              `cbor:"21,keyasint,omitempty"`
+	Donation             uint64               `cbor:"22,keyasint,omitempty"`
+
+	raw []byte
+	// unknown are the decoded fields without a TxBody field, e.g. withdrawals or minted assets
+	unknown unknownEntries
 }
 
 // NewTxBody returns a pointer to a new transaction body.
@@ -37,3 +51,74 @@ func (b *TxBody) Hex() (string, error) {
 	}
 	return hex.EncodeToString(by), nil
 }
+
+// MarshalCBOR returns the cbor encoding of the transaction body, the original encoding for decoded bodies.
+func (b *TxBody) MarshalCBOR() ([]byte, error) {
+	if b.raw != nil {
+		return b.raw, nil
+	}
+	type txBody TxBody
+	data, err := cbor.Marshal((*txBody)(b))
+	if err != nil {
+		return nil, err
+	}
+	return b.unknown.merge(data)
+}
+
+// UnmarshalCBOR deserializes a cbor encoded transaction body.
+func (b *TxBody) UnmarshalCBOR(data []byte) error {
+	keys, values, err := unmarshalMap(data)
+	if err != nil {
+		return err
+	}
+
+	body := TxBody{raw: append([]byte{}, data...)}
+	for i := range keys {
+		var key uint64
+		if err := cbor.Unmarshal(keys[i], &key); err != nil {
+			return err
+		}
+
+		var field interface{}
+		switch key {
+		case 0:
+			field = &body.Inputs
+		case 1:
+			field = &body.Outputs
+		case 2:
+			field = &body.Fee
+		case 3:
+			field = &body.TTL
+		case 4:
+			if body.Certificates, err = unmarshalCertificates(values[i]); err != nil {
+				return err
+			}
+			continue
+		case 7:
+			field = &body.AuxiliaryDataHash
+		case 19:
+			field = &body.VotingProcedures
+		case 20:
+			field = &body.ProposalProcedures
+		case 21:
+			field = &body.CurrentTreasuryValue
+		case 22:
+			field = &body.Donation
+		default:
+			body.unknown.add(keys[i], values[i])
+			continue
+		}
+		if err := cbor.Unmarshal(values[i], field); err != nil {
+			return err
+		}
+	}
+
+	*b = body
+	return nil
+}
+
+// ResetEncoding discards the original encoding of a decoded body, so changes made to its fields are encoded.
+// Fields the TxBody does not have, e.g. withdrawals, are kept.
+func (b *TxBody) ResetEncoding() {
+	b.raw = nil
+}
@@ -1,37 +1,62 @@
 package tx
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/fivebinaries/go-cardano-serialization/address"
 	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
 	"github.com/fivebinaries/go-cardano-serialization/fees"
 	"github.com/fivebinaries/go-cardano-serialization/protocol"
 )
 
+var (
+	ErrInvalidDeposit = errors.New("deposit does not match protocol parameters")
+	ErrInvalidRefund  = errors.New("refund does not match the registered deposit")
+)
+
 // TxBuilder - used to create, validate and sign transactions.
 type TxBuilder struct {
-	tx       *Tx
-	xprvs    []bip32.XPrv
-	protocol protocol.Protocol
+	tx              *Tx
+	signers         []Signer
+	protocol        protocol.Protocol
+	registeredPools map[crypto.Ed25519KeyHash]bool
 }
 
 // Sign adds a private key to create signature for witness
 func (tb *TxBuilder) Sign(xprv bip32.XPrv) {
-	tb.xprvs = append(tb.xprvs, xprv)
+	tb.AddSigners(NewExtendedKeySigner(xprv))
+}
+
+// AddSigners adds signers to create the witnesses of the transaction.
+func (tb *TxBuilder) AddSigners(signers ...Signer) {
+	tb.signers = append(tb.signers, signers...)
 }
 
 // Build creates hash of transaction, signs the hash using supplied witnesses and adds them to the transaction.
+// Verification-only signers are left for the caller to witness, but count towards the required witnesses.
 func (tb *TxBuilder) Build() (tx Tx, err error) {
+	if err := tb.validateDeposits(); err != nil {
+		return tx, err
+	}
+
 	hash, err := tb.tx.Hash()
 	if err != nil {
 		return tx, err
 	}
 
 	txKeys := []*VKeyWitness{}
-	for _, prv := range tb.xprvs {
-		publicKey := prv.Public().PublicKey()
-		signature := prv.Sign(hash[:])
+	for _, signer := range tb.signers {
+		signature, err := signer.Sign(hash[:])
+		if errors.Is(err, ErrVerificationOnly) {
+			continue
+		}
+		if err != nil {
+			return tx, err
+		}
 
-		txKeys = append(txKeys, NewVKeyWitness(publicKey, signature[:]))
+		txKeys = append(txKeys, NewVKeyWitness(signer.PublicKey(), signature))
 	}
 
 	tb.tx.Witness = NewTXWitness(
@@ -52,7 +77,9 @@ func (tb *TxBuilder) AddChangeIfNeeded(addr address.Address) {
 	tb.tx.SetFee(tb.MinFee())
 	totalI, totalO := tb.getTotalInputOutputs()
 
-	change := totalI - totalO - uint(tb.tx.Body.Fee)
+	deposit, refund := tb.getDeposits()
+
+	change := totalI + refund - totalO - deposit - uint(tb.tx.Body.Fee)
 	tb.tx.AddOutputs(
 		NewTxOutput(
 			addr,
@@ -77,34 +104,205 @@ func (tb TxBuilder) getTotalInputOutputs() (inputs, outputs uint) {
 	return
 }
 
+// getDeposits returns the deposits paid and refunded by the certificates of the transaction.
+func (tb TxBuilder) getDeposits() (deposit, refund uint) {
+	for _, cert := range tb.tx.Body.Certificates {
+		switch c := cert.(type) {
+		case *PoolRegistration:
+			if !tb.registeredPools[c.Operator] {
+				deposit += tb.protocol.PoolDeposit
+			}
+		case *StakeRegistration:
+			if c.Deposit != nil {
+				deposit += uint(*c.Deposit)
+			} else {
+				deposit += tb.protocol.KeyDeposit
+			}
+		case *StakeDeregistration:
+			if c.Refund != nil {
+				refund += uint(*c.Refund)
+			} else {
+				refund += tb.protocol.KeyDeposit
+			}
+		case *StakeRegistrationDelegation:
+			deposit += uint(c.Deposit)
+		case *VoteRegistrationDelegation:
+			deposit += uint(c.Deposit)
+		case *StakeVoteRegistrationDelegation:
+			deposit += uint(c.Deposit)
+		case *DRepRegistration:
+			deposit += uint(c.Deposit)
+		case *DRepDeregistration:
+			refund += uint(c.Refund)
+		}
+	}
+	for _, proposal := range tb.tx.Body.ProposalProcedures {
+		deposit += uint(proposal.Deposit)
+	}
+	deposit += uint(tb.tx.Body.Donation)
+
+	return
+}
+
+// credentialKey returns a map key identifying the credential.
+func credentialKey(cred address.StakeCredential) string {
+	return string(append([]byte{byte(cred.Kind)}, cred.Payload...))
+}
+
+// validateDeposits checks the explicit deposits and refunds of the certificates against the protocol parameters.
+// Refunds of credentials registered in the same transaction are checked against their registration deposit.
+// Deposits and refunds are not checked when the corresponding protocol parameter is unset.
+func (tb TxBuilder) validateDeposits() error {
+	keyDeposits := map[string]uint{}
+	drepDeposits := map[string]uint{}
+	for _, cert := range tb.tx.Body.Certificates {
+		var deposit *uint64
+		var expected uint
+
+		switch c := cert.(type) {
+		case *StakeRegistration:
+			deposit, expected = c.Deposit, tb.protocol.KeyDeposit
+			if c.Deposit != nil {
+				keyDeposits[credentialKey(c.Stake)] = uint(*c.Deposit)
+			} else if tb.protocol.KeyDeposit != 0 {
+				keyDeposits[credentialKey(c.Stake)] = tb.protocol.KeyDeposit
+			}
+		case *StakeRegistrationDelegation:
+			deposit, expected = &c.Deposit, tb.protocol.KeyDeposit
+			keyDeposits[credentialKey(c.Stake)] = uint(c.Deposit)
+		case *VoteRegistrationDelegation:
+			deposit, expected = &c.Deposit, tb.protocol.KeyDeposit
+			keyDeposits[credentialKey(c.Stake)] = uint(c.Deposit)
+		case *StakeVoteRegistrationDelegation:
+			deposit, expected = &c.Deposit, tb.protocol.KeyDeposit
+			keyDeposits[credentialKey(c.Stake)] = uint(c.Deposit)
+		case *DRepRegistration:
+			deposit, expected = &c.Deposit, tb.protocol.DRepDeposit
+			drepDeposits[credentialKey(c.Credential)] = uint(c.Deposit)
+		}
+
+		if deposit != nil && expected != 0 && uint(*deposit) != expected {
+			return fmt.Errorf("%w: certificate type %d has deposit %d, expected %d", ErrInvalidDeposit, cert.Type(), *deposit, expected)
+		}
+	}
+	for _, cert := range tb.tx.Body.Certificates {
+		var refund *uint64
+		var expected uint
+		var registered bool
+
+		switch c := cert.(type) {
+		case *StakeDeregistration:
+			refund = c.Refund
+			if expected, registered = keyDeposits[credentialKey(c.Stake)]; !registered {
+				expected = tb.protocol.KeyDeposit
+			}
+		case *DRepDeregistration:
+			refund = &c.Refund
+			if expected, registered = drepDeposits[credentialKey(c.Credential)]; !registered {
+				expected = tb.protocol.DRepDeposit
+			}
+		}
+
+		if refund != nil && (expected != 0 || registered) && uint(*refund) != expected {
+			return fmt.Errorf("%w: certificate type %d has refund %d, expected %d", ErrInvalidRefund, cert.Type(), *refund, expected)
+		}
+	}
+	for _, proposal := range tb.tx.Body.ProposalProcedures {
+		expected := tb.protocol.GovActionDeposit
+		if expected != 0 && uint(proposal.Deposit) != expected {
+			return fmt.Errorf("%w: proposal has deposit %d, expected %d", ErrInvalidDeposit, proposal.Deposit, expected)
+		}
+	}
+
+	return nil
+}
+
+// requiredKeyHashes returns the key hashes of the witnesses required by the certificates and voters of the transaction.
+func (tb TxBuilder) requiredKeyHashes() (keyHashes []crypto.Ed25519KeyHash) {
+	seen := map[crypto.Ed25519KeyHash]bool{}
+	for _, cert := range tb.tx.Body.Certificates {
+		for _, keyHash := range certificateWitnesses(cert) {
+			if !seen[keyHash] {
+				seen[keyHash] = true
+				keyHashes = append(keyHashes, keyHash)
+			}
+		}
+	}
+	if tb.tx.Body.VotingProcedures == nil {
+		return
+	}
+	for voter := range *tb.tx.Body.VotingProcedures {
+		if keyHash, ok := voter.KeyHash(); ok && !seen[keyHash] {
+			seen[keyHash] = true
+			keyHashes = append(keyHashes, keyHash)
+		}
+	}
+
+	return
+}
+
+// MissingSigners returns the key hashes required by the certificates and voters of the transaction which
+// no signer of the builder holds, e.g. pool owners witnessing the transaction offline. The fee accounts
+// for their witnesses, which have to be added to the built transaction before it is submitted.
+func (tb TxBuilder) MissingSigners() (keyHashes []crypto.Ed25519KeyHash) {
+	signers := tb.signerKeyHashes()
+	for _, keyHash := range tb.requiredKeyHashes() {
+		if !signers[keyHash] {
+			keyHashes = append(keyHashes, keyHash)
+		}
+	}
+
+	return
+}
+
+// signerKeyHashes returns the key hashes of the signers of the transaction.
+func (tb TxBuilder) signerKeyHashes() map[crypto.Ed25519KeyHash]bool {
+	signers := map[crypto.Ed25519KeyHash]bool{}
+	for _, signer := range tb.signers {
+		signers[signer.PublicKey().Hash()] = true
+	}
+
+	return signers
+}
+
 // MinFee calculates the minimum fee for the provided transaction.
 func (tb TxBuilder) MinFee() (fee uint) {
+	// The fee is calculated on copies, the dummy witnesses and change must not end up in the transaction.
+	feeBody := *tb.tx.Body
+	feeBody.Outputs = append([]*TxOutput{}, tb.tx.Body.Outputs...)
+	feeBody.ResetEncoding()
+	feeWitness := *tb.tx.Witness
+	feeWitness.Keys = append([]*VKeyWitness{}, tb.tx.Witness.Keys...)
 	feeTx := Tx{
-		Body: &TxBody{
-			Inputs:  tb.tx.Body.Inputs,
-			Outputs: tb.tx.Body.Outputs,
-			Fee:     tb.tx.Body.Fee,
-			TTL:     tb.tx.Body.TTL,
-		},
-		Witness:  tb.tx.Witness,
-		Valid:    true,
-		Metadata: tb.tx.Metadata,
+		Body:          &feeBody,
+		Witness:       &feeWitness,
+		Valid:         true,
+		AuxiliaryData: tb.tx.AuxiliaryData,
 	}
 	feeTx.CalculateAuxiliaryDataHash()
 	if len(feeTx.Witness.Keys) == 0 {
-		vWitness := NewVKeyWitness(
-			make([]byte, 32),
-			make([]byte, 64),
-		)
-		feeTx.Witness.Keys = append(feeTx.Witness.Keys, vWitness)
+		// One dummy witness per signing key and per key required by the certificates,
+		// but at least one for spending the inputs.
+		signers := tb.signerKeyHashes()
+		for _, keyHash := range tb.requiredKeyHashes() {
+			signers[keyHash] = true
+		}
+		for i := 0; i < len(signers) || i == 0; i++ {
+			vWitness := NewVKeyWitness(
+				make([]byte, 32),
+				make([]byte, 64),
+			)
+			feeTx.Witness.Keys = append(feeTx.Witness.Keys, vWitness)
+		}
 	}
 
 	totalI, totalO := tb.getTotalInputOutputs()
+	deposit, refund := tb.getDeposits()
 
-	if totalI != (totalO) {
+	if totalI+refund != totalO+deposit {
 		inner_addr, _ := address.NewAddress("addr_test1qqe6zztejhz5hq0xghlf72resflc4t2gmu9xjlf73x8dpf88d78zlt4rng3ccw8g5vvnkyrvt96mug06l5eskxh8rcjq2wyd63")
 
-		feeTx.Body.Outputs = append(feeTx.Body.Outputs, NewTxOutput(inner_addr, (totalI-totalO-200000)))
+		feeTx.Body.Outputs = append(feeTx.Body.Outputs, NewTxOutput(inner_addr, (totalI+refund-totalO-deposit-200000)))
 
 	}
 	lfee := fees.NewLinearFee(tb.protocol.TxFeePerByte, tb.protocol.TxFeeFixed)
@@ -126,11 +324,125 @@ func (tb *TxBuilder) AddOutputs(outputs ...*TxOutput) {
 	tb.tx.AddOutputs(outputs...)
 }
 
+// AddCertificates adds certificates to the transaction body. Their deposits are accounted for
+// when calculating the change, MissingSigners reports the keys which still have to witness them.
+func (tb *TxBuilder) AddCertificates(certs ...Certificate) {
+	tb.tx.Body.Certificates = append(tb.tx.Body.Certificates, certs...)
+}
+
+// SetMetadata sets the metadata of the transaction after checking it against the ledger rules.
+// Scripts already attached to the auxiliary data are kept.
+func (tb *TxBuilder) SetMetadata(metadata TransactionMetadata) error {
+	if err := metadata.Validate(); err != nil {
+		return err
+	}
+	if tb.tx.AuxiliaryData == nil {
+		tb.tx.AuxiliaryData = NewAuxiliaryData(metadata, nil, nil)
+		return nil
+	}
+	tb.tx.AuxiliaryData.Metadata = metadata
+	tb.tx.AuxiliaryData.ResetEncoding()
+	return nil
+}
+
+// addMetadata adds the labels of metadata to the metadata of the transaction, replacing existing labels.
+func (tb *TxBuilder) addMetadata(metadata TransactionMetadata) error {
+	if tb.tx.AuxiliaryData == nil || tb.tx.AuxiliaryData.Metadata == nil {
+		return tb.SetMetadata(metadata)
+	}
+	if err := metadata.Validate(); err != nil {
+		return err
+	}
+	for label, metadatum := range metadata {
+		tb.tx.AuxiliaryData.Metadata[label] = metadatum
+	}
+	tb.tx.AuxiliaryData.ResetEncoding()
+	return nil
+}
+
+// SetMessage sets the CIP-20 message of the transaction, keeping other metadata.
+func (tb *TxBuilder) SetMessage(lines ...string) error {
+	return tb.addMetadata(NewMessageMetadata(lines...))
+}
+
+// SetEncryptedMessage sets the CIP-20 message of the transaction encrypted with the passphrase per CIP-83,
+// keeping other metadata.
+func (tb *TxBuilder) SetEncryptedMessage(passphrase string, lines ...string) error {
+	metadata, err := NewEncryptedMessageMetadata(passphrase, lines...)
+	if err != nil {
+		return err
+	}
+	return tb.addMetadata(metadata)
+}
+
+// SetCIP36Registration sets the CIP-36 vote key registration of the transaction signed by the stake key,
+// keeping other metadata.
+func (tb *TxBuilder) SetCIP36Registration(registration *CIP36Registration, stakeKey bip32.XPrv) error {
+	metadata, err := registration.Sign(stakeKey)
+	if err != nil {
+		return err
+	}
+	return tb.addMetadata(metadata)
+}
+
+// SetAuxiliaryData sets the auxiliary data of the transaction, replacing any metadata set before.
+func (tb *TxBuilder) SetAuxiliaryData(aux *AuxiliaryData) error {
+	if err := aux.Validate(); err != nil {
+		return err
+	}
+	tb.tx.AuxiliaryData = aux
+	return nil
+}
+
+// AddVote adds the vote of a voter on a governance action to the transaction body.
+// The voter's key is required to witness the transaction.
+func (tb *TxBuilder) AddVote(voter *Voter, actionId GovActionId, procedure VotingProcedure) {
+	if tb.tx.Body.VotingProcedures == nil {
+		tb.tx.Body.VotingProcedures = &VotingProcedures{}
+	}
+	procedures := *tb.tx.Body.VotingProcedures
+	if procedures[*voter] == nil {
+		procedures[*voter] = map[GovActionId]VotingProcedure{}
+	}
+	procedures[*voter][actionId] = procedure
+}
+
+// AddProposals adds proposal procedures to the transaction body. Their deposits are accounted for
+// when calculating the change.
+func (tb *TxBuilder) AddProposals(proposals ...*ProposalProcedure) {
+	tb.tx.Body.ProposalProcedures = append(tb.tx.Body.ProposalProcedures, proposals...)
+}
+
+// SetCurrentTreasuryValue sets the expected value of the treasury, which is checked by the ledger
+// when the transaction is applied.
+func (tb *TxBuilder) SetCurrentTreasuryValue(value uint64) {
+	tb.tx.Body.CurrentTreasuryValue = &value
+}
+
+// SetDonation sets the amount of lovelace donated to the treasury.
+func (tb *TxBuilder) SetDonation(donation uint64) {
+	tb.tx.Body.Donation = donation
+}
+
+// MarkPoolRegistered marks stake pools as already registered. Their registration certificates
+// update the pool parameters and do not require a pool deposit.
+func (tb *TxBuilder) MarkPoolRegistered(operators ...crypto.Ed25519KeyHash) {
+	if tb.registeredPools == nil {
+		tb.registeredPools = map[crypto.Ed25519KeyHash]bool{}
+	}
+	for _, operator := range operators {
+		tb.registeredPools[operator] = true
+	}
+}
+
 // NewTxBuilder returns pointer to a new TxBuilder.
 func NewTxBuilder(pr protocol.Protocol, xprvs []bip32.XPrv) *TxBuilder {
-	return &TxBuilder{
+	tb := &TxBuilder{
 		tx:       NewTx(),
-		xprvs:    xprvs,
 		protocol: pr,
 	}
+	for _, xprv := range xprvs {
+		tb.Sign(xprv)
+	}
+	return tb
 }
@@ -0,0 +1,216 @@
+package tx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// cborHeader returns the initial bytes of a cbor data item of the major type with argument n.
+func cborHeader(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n <= 0xff:
+		return []byte{major<<5 | 24, byte(n)}
+	case n <= 0xffff:
+		header := []byte{major<<5 | 25, 0, 0}
+		binary.BigEndian.PutUint16(header[1:], uint16(n))
+		return header
+	case n <= 0xffffffff:
+		header := []byte{major<<5 | 26, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(header[1:], uint32(n))
+		return header
+	default:
+		header := []byte{major<<5 | 27, 0, 0, 0, 0, 0, 0, 0, 0}
+		binary.BigEndian.PutUint64(header[1:], n)
+		return header
+	}
+}
+
+// marshalMap returns the cbor map of the already encoded keys and values. The entries are sorted
+// by their encoded keys in canonical order so the encoding does not depend on map iteration order.
+func marshalMap(keys, values [][]byte) []byte {
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := keys[order[i]], keys[order[j]]
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return bytes.Compare(a, b) < 0
+	})
+
+	out := cborHeader(5, uint64(len(keys)))
+	for _, i := range order {
+		out = append(out, keys[i]...)
+		out = append(out, values[i]...)
+	}
+	return out
+}
+
+// cborHeaderLen returns the length of the initial bytes of the cbor data item starting with initial.
+func cborHeaderLen(initial byte) (int, error) {
+	switch info := initial & 0x1f; {
+	case info < 24, info == 31:
+		return 1, nil
+	case info <= 27:
+		return 1 + 1<<(info-24), nil
+	}
+	return 0, errors.New("invalid cbor header")
+}
+
+// unmarshalMap returns the encoded keys and values of a cbor map in the order they appear in data.
+func unmarshalMap(data []byte) (keys, values []cbor.RawMessage, err error) {
+	if len(data) == 0 || data[0]>>5 != 5 {
+		return nil, nil, errors.New("cbor data item is not a map")
+	}
+	headerLen, err := cborHeaderLen(data[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(data) < headerLen {
+		return nil, nil, io.ErrUnexpectedEOF
+	}
+
+	indefinite := data[0]&0x1f == 31
+	var n uint64
+	switch headerLen {
+	case 1:
+		n = uint64(data[0] & 0x1f)
+	case 2:
+		n = uint64(data[1])
+	case 3:
+		n = uint64(binary.BigEndian.Uint16(data[1:]))
+	case 5:
+		n = uint64(binary.BigEndian.Uint32(data[1:]))
+	case 9:
+		n = binary.BigEndian.Uint64(data[1:])
+	}
+
+	body := data[headerLen:]
+	dec := cbor.NewDecoder(bytes.NewReader(body))
+	for i := uint64(0); indefinite || i < n; i++ {
+		if indefinite {
+			if read := dec.NumBytesRead(); read < len(body) && body[read] == 0xff {
+				break
+			}
+		}
+		var key, value cbor.RawMessage
+		if err := dec.Decode(&key); err != nil {
+			return nil, nil, err
+		}
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return keys, values, nil
+}
+
+// unmarshalArray decodes the elements of a cbor array into the values. It returns errInvalid if the array
+// does not have one element per value.
+func unmarshalArray(data []byte, errInvalid error, values ...interface{}) error {
+	var raw []cbor.RawMessage
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != len(values) {
+		return errInvalid
+	}
+	for i, value := range values {
+		if err := cbor.Unmarshal(raw[i], value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unmarshalTypedArray decodes a cbor array whose first element is the type into the values of the remaining
+// elements. It returns errInvalid if the array is of another type or does not have one element per value.
+func unmarshalTypedArray(data []byte, typ uint, errInvalid error, values ...interface{}) error {
+	var raw []cbor.RawMessage
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != len(values)+1 {
+		return errInvalid
+	}
+
+	var actual uint
+	if err := cbor.Unmarshal(raw[0], &actual); err != nil {
+		return err
+	}
+	if actual != typ {
+		return errInvalid
+	}
+
+	for i, value := range values {
+		if err := cbor.Unmarshal(raw[i+1], value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fixedBytes decodes a cbor byte string into a hash or key, rejecting byte strings of another length.
+type fixedBytes []byte
+
+// fixed returns a pointer to the fixedBytes decoding into b, e.g. fixed(keyHash[:]).
+func fixed(b []byte) *fixedBytes {
+	f := fixedBytes(b)
+	return &f
+}
+
+func (f *fixedBytes) UnmarshalCBOR(data []byte) error {
+	var raw []byte
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != len(*f) {
+		return errors.New("unexpected byte string length")
+	}
+	copy(*f, raw)
+	return nil
+}
+
+// unknownEntries are the entries of a decoded cbor map without a matching field. They are kept so the map
+// can be encoded again without losing them.
+type unknownEntries struct {
+	keys, values [][]byte
+}
+
+func (u *unknownEntries) add(key, value []byte) {
+	u.keys = append(u.keys, key)
+	u.values = append(u.values, value)
+}
+
+// merge returns the cbor map data with the unknown entries added.
+func (u *unknownEntries) merge(data []byte) ([]byte, error) {
+	if len(u.keys) == 0 {
+		return data, nil
+	}
+	rawKeys, rawValues, err := unmarshalMap(data)
+	if err != nil {
+		return nil, err
+	}
+	keys := append([][]byte{}, u.keys...)
+	values := append([][]byte{}, u.values...)
+	for i := range rawKeys {
+		keys = append(keys, rawKeys[i])
+		values = append(values, rawValues[i])
+	}
+	return marshalMap(keys, values), nil
+}
+
+// isNull reports whether the cbor data item is null or undefined.
+func isNull(data []byte) bool {
+	return len(data) == 1 && (data[0] == 0xf6 || data[0] == 0xf7)
+}
@@ -0,0 +1,221 @@
+package tx
+
+import (
+	"errors"
+
+	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fxamacker/cbor/v2"
+)
+
+var (
+	ErrInvalidUnitInterval = errors.New("invalid unit interval")
+	ErrInvalidCertificate  = errors.New("invalid certificate")
+)
+
+// CertificateType is the tag identifying the kind of a certificate in its cbor encoding.
+type CertificateType uint
+
+const (
+	StakeRegistrationCertificateType CertificateType = iota
+	StakeDeregistrationCertificateType
+	StakeDelegationCertificateType
+	PoolRegistrationCertificateType
+	PoolRetirementCertificateType
+	GenesisKeyDelegationCertificateType
+	MoveInstantaneousRewardsCertificateType
+	RegistrationCertificateType
+	UnregistrationCertificateType
+	VoteDelegationCertificateType
+	StakeVoteDelegationCertificateType
+	StakeRegistrationDelegationCertificateType
+	VoteRegistrationDelegationCertificateType
+	StakeVoteRegistrationDelegationCertificateType
+	CommitteeHotAuthCertificateType
+	CommitteeColdResignCertificateType
+	DRepRegistrationCertificateType
+	DRepDeregistrationCertificateType
+	DRepUpdateCertificateType
+)
+
+// Certificate is a certificate included in the transaction body.
+type Certificate interface {
+	cbor.Marshaler
+
+	// Type returns the certificate type
+	Type() CertificateType
+}
+
+// UnitInterval is a rational number in the interval [0, 1], e.g. a stake pool margin.
+type UnitInterval struct {
+	Numerator   uint64
+	Denominator uint64
+}
+
+// NewUnitInterval returns a pointer to a new UnitInterval of numerator/denominator.
+func NewUnitInterval(numerator, denominator uint64) (*UnitInterval, error) {
+	u := &UnitInterval{
+		Numerator:   numerator,
+		Denominator: denominator,
+	}
+	if err := u.Validate(); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Validate checks that the denominator is positive and the value is at most 1.
+func (u UnitInterval) Validate() error {
+	if u.Denominator == 0 || u.Numerator > u.Denominator {
+		return ErrInvalidUnitInterval
+	}
+	return nil
+}
+
+// MarshalCBOR returns the cbor encoding of the unit interval as a rational number (tag 30).
+func (u UnitInterval) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(cbor.Tag{
+		Number:  30,
+		Content: []uint64{u.Numerator, u.Denominator},
+	})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded rational number into the unit interval.
+func (u *UnitInterval) UnmarshalCBOR(data []byte) error {
+	var raw []uint64
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 2 {
+		return ErrInvalidUnitInterval
+	}
+	u.Numerator, u.Denominator = raw[0], raw[1]
+	return nil
+}
+
+// unmarshalCertificate deserializes a cbor encoded certificate of any type but the genesis key delegation and
+// move instantaneous rewards certificates, which cannot be submitted since conway.
+func unmarshalCertificate(data []byte) (Certificate, error) {
+	var raw []cbor.RawMessage
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return nil, ErrInvalidCertificate
+	}
+	var typ CertificateType
+	if err := cbor.Unmarshal(raw[0], &typ); err != nil {
+		return nil, err
+	}
+
+	var cert Certificate
+	switch typ {
+	case StakeRegistrationCertificateType, RegistrationCertificateType:
+		cert = &StakeRegistration{}
+	case StakeDeregistrationCertificateType, UnregistrationCertificateType:
+		cert = &StakeDeregistration{}
+	case StakeDelegationCertificateType:
+		cert = &StakeDelegation{}
+	case PoolRegistrationCertificateType:
+		cert = &PoolRegistration{}
+	case PoolRetirementCertificateType:
+		cert = &PoolRetirement{}
+	case VoteDelegationCertificateType:
+		cert = &VoteDelegation{}
+	case StakeVoteDelegationCertificateType:
+		cert = &StakeVoteDelegation{}
+	case StakeRegistrationDelegationCertificateType:
+		cert = &StakeRegistrationDelegation{}
+	case VoteRegistrationDelegationCertificateType:
+		cert = &VoteRegistrationDelegation{}
+	case StakeVoteRegistrationDelegationCertificateType:
+		cert = &StakeVoteRegistrationDelegation{}
+	case CommitteeHotAuthCertificateType:
+		cert = &CommitteeHotAuth{}
+	case CommitteeColdResignCertificateType:
+		cert = &CommitteeColdResign{}
+	case DRepRegistrationCertificateType:
+		cert = &DRepRegistration{}
+	case DRepDeregistrationCertificateType:
+		cert = &DRepDeregistration{}
+	case DRepUpdateCertificateType:
+		cert = &DRepUpdate{}
+	default:
+		return nil, ErrInvalidCertificate
+	}
+
+	if err := cbor.Unmarshal(data, cert); err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
+
+// unmarshalCertificates deserializes the cbor encoded certificates of a transaction body.
+func unmarshalCertificates(data []byte) ([]Certificate, error) {
+	var raw []cbor.RawMessage
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	certs := make([]Certificate, 0, len(raw))
+	for _, rawCert := range raw {
+		cert, err := unmarshalCertificate(rawCert)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// certificateWitnesses returns the key hashes which have to sign a transaction including the certificate.
+// Witnesses of script credentials are not key hashes and are left to the caller.
+func certificateWitnesses(cert Certificate) (keyHashes []crypto.Ed25519KeyHash) {
+	var creds []*address.StakeCredential
+
+	switch c := cert.(type) {
+	case *PoolRegistration:
+		keyHashes = append(keyHashes, c.Operator)
+		keyHashes = append(keyHashes, c.Owners...)
+	case *PoolRetirement:
+		keyHashes = append(keyHashes, c.Operator)
+	case *StakeRegistration:
+		// Only the conway registration carrying an explicit deposit has to be witnessed.
+		if c.Deposit != nil {
+			creds = append(creds, &c.Stake)
+		}
+	case *StakeDeregistration:
+		creds = append(creds, &c.Stake)
+	case *StakeDelegation:
+		creds = append(creds, &c.Stake)
+	case *VoteDelegation:
+		creds = append(creds, &c.Stake)
+	case *StakeVoteDelegation:
+		creds = append(creds, &c.Stake)
+	case *StakeRegistrationDelegation:
+		creds = append(creds, &c.Stake)
+	case *VoteRegistrationDelegation:
+		creds = append(creds, &c.Stake)
+	case *StakeVoteRegistrationDelegation:
+		creds = append(creds, &c.Stake)
+	case *CommitteeHotAuth:
+		creds = append(creds, &c.ColdCredential)
+	case *CommitteeColdResign:
+		creds = append(creds, &c.ColdCredential)
+	case *DRepRegistration:
+		creds = append(creds, &c.Credential)
+	case *DRepDeregistration:
+		creds = append(creds, &c.Credential)
+	case *DRepUpdate:
+		creds = append(creds, &c.Credential)
+	}
+
+	for _, cred := range creds {
+		if cred.Kind != address.KeyStakeCredentialType {
+			continue
+		}
+		if keyHash, err := crypto.Ed25519KeyHashFromBytes(cred.Payload); err == nil {
+			keyHashes = append(keyHashes, keyHash)
+		}
+	}
+	return
+}
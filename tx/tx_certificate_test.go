@@ -0,0 +1,318 @@
+package tx_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fivebinaries/go-cardano-serialization/network"
+	"github.com/fivebinaries/go-cardano-serialization/protocol"
+	"github.com/fivebinaries/go-cardano-serialization/tx"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolCertificateEncoding(t *testing.T) {
+	var operator crypto.Ed25519KeyHash
+	for i := range operator {
+		operator[i] = 0x01
+	}
+
+	ipv4Relay, err := tx.NewSingleHostAddr(3001, net.ParseIP("1.2.3.4"), nil)
+	assert.NoError(t, err)
+	ipv6Relay, err := tx.NewSingleHostAddr(0, nil, net.ParseIP("2001:db8::1"))
+	assert.NoError(t, err)
+	multiRelay, err := tx.NewMultiHostName("relays.example.com")
+	assert.NoError(t, err)
+
+	_, err = tx.NewSingleHostAddr(3001, nil, nil)
+	assert.ErrorIs(t, err, tx.ErrInvalidRelay)
+
+	scenarios := []struct {
+		description string
+		value       cbor.Marshaler
+		decoded     cbor.Unmarshaler
+		cborHex     string
+	}{
+		{
+			description: "pool retirement",
+			value:       tx.NewPoolRetirement(operator, 300),
+			decoded:     &tx.PoolRetirement{},
+			cborHex:     "8304581c" + hex.EncodeToString(operator[:]) + "19012c",
+		},
+		{
+			description: "single host ipv4 relay",
+			value:       ipv4Relay,
+			decoded:     &tx.SingleHostAddr{},
+			cborHex:     "8400190bb94401020304f6",
+		},
+		{
+			description: "single host ipv6 relay",
+			value:       ipv6Relay,
+			decoded:     &tx.SingleHostAddr{},
+			cborHex:     "8400f6f650b80d0120000000000000000001000000",
+		},
+		{
+			description: "multi host name relay",
+			value:       multiRelay,
+			decoded:     &tx.MultiHostName{},
+			cborHex:     "8202" + "72" + hex.EncodeToString([]byte("relays.example.com")),
+		},
+	}
+
+	for _, sc := range scenarios {
+		t.Run(sc.description, func(t *testing.T) {
+			data, err := sc.value.MarshalCBOR()
+			assert.NoError(t, err)
+			assert.Equal(t, sc.cborHex, hex.EncodeToString(data))
+			assert.NoError(t, cbor.Unmarshal(data, sc.decoded))
+			assert.Equal(t, sc.value, sc.decoded)
+		})
+	}
+}
+
+func TestTxBuilderPoolRegistration(t *testing.T) {
+	rootKey := createRootKey()
+	addr, utxoPrv, err := generateBaseAddress(network.TestNet())
+	if err != nil {
+		t.Fatal(err)
+	}
+	coldPrv := rootKey.Derive(harden(1853)).Derive(harden(1815)).Derive(harden(0)).Derive(harden(0))
+	operator := coldPrv.Public().PublicKey().Hash()
+
+	margin, err := tx.NewUnitInterval(1, 50)
+	assert.NoError(t, err)
+	metadata, err := tx.NewPoolMetadata("https://example.com/pool.json", crypto.MetadataHash{})
+	assert.NoError(t, err)
+
+	owner, err := crypto.Ed25519KeyHashFromBytes(addr.Stake.Payload)
+	assert.NoError(t, err)
+
+	cert := &tx.PoolRegistration{
+		Operator:      operator,
+		Pledge:        100000000,
+		Cost:          340000000,
+		Margin:        *margin,
+		RewardAccount: addr.ToReward(),
+		Owners:        []crypto.Ed25519KeyHash{owner},
+		Metadata:      metadata,
+	}
+	data, err := cert.MarshalCBOR()
+	assert.NoError(t, err)
+	decodedCert := &tx.PoolRegistration{}
+	assert.NoError(t, cbor.Unmarshal(data, decodedCert))
+	assert.Equal(t, cert, decodedCert)
+
+	pr := protocol.Protocol{TxFeePerByte: 44, TxFeeFixed: 155381, PoolDeposit: 500000000}
+	newBuilder := func(registered bool, keys ...bip32.XPrv) *tx.TxBuilder {
+		builder := tx.NewTxBuilder(pr, keys)
+		builder.AddInputs(tx.NewTxInput("fcbc18c64cdf133f33dd319c5105dc7c4972f2d646ae276fbd00cf7f39f8c380", 0, 1000000000))
+		builder.AddCertificates(cert)
+		if registered {
+			builder.MarkPoolRegistered(operator)
+		}
+		builder.AddChangeIfNeeded(addr)
+		return builder
+	}
+
+	// The operator and owner witness the registration offline.
+	builder := newBuilder(false, utxoPrv)
+	txBody := builder.Tx().Body
+	assert.Equal(t, uint(1000000000-500000000)-uint(txBody.Fee), txBody.Outputs[0].Amount)
+	assert.Equal(t, []crypto.Ed25519KeyHash{operator, owner}, builder.MissingSigners())
+
+	stakePrv := rootKey.Derive(harden(1852)).Derive(harden(1815)).Derive(harden(0)).Derive(2).Derive(0)
+	signed := newBuilder(false, utxoPrv, coldPrv, stakePrv)
+	assert.Empty(t, signed.MissingSigners())
+	assert.Equal(t, signed.MinFee(), builder.MinFee())
+
+	txFinal, err := builder.Build()
+	assert.NoError(t, err)
+	assert.Len(t, txFinal.Witness.Keys, 1)
+	txFinal, err = signed.Build()
+	assert.NoError(t, err)
+
+	data, err = txFinal.Bytes()
+	assert.NoError(t, err)
+	decoded := &tx.Tx{}
+	assert.NoError(t, cbor.Unmarshal(data, decoded))
+	assert.Equal(t, []tx.Certificate{cert}, decoded.Body.Certificates)
+	invalid, err := decoded.VerifyWitnesses()
+	assert.NoError(t, err)
+	assert.Empty(t, invalid)
+
+	builder = newBuilder(true, utxoPrv, coldPrv, stakePrv)
+	txBody = builder.Tx().Body
+	assert.Equal(t, uint(1000000000)-uint(txBody.Fee), txBody.Outputs[0].Amount)
+}
+
+func TestPoolMetadata(t *testing.T) {
+	data := []byte(`{"name":"Example Pool","description":"An example stake pool","ticker":"EXMPL","homepage":"https://example.com"}`)
+
+	content, err := tx.ParsePoolMetadata(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "EXMPL", content.Ticker)
+
+	hash := tx.PoolMetadataHash(data)
+	assert.Equal(t, "c9d4d952262f3e07e0d3c371e802493bba9b4c1f70c9f1e2707c0a2924d07e7b", hex.EncodeToString(hash[:]))
+
+	metadata, err := tx.NewPoolMetadataFromContent("https://example.com/pool.json", data)
+	assert.NoError(t, err)
+	cert := &tx.PoolRegistration{Metadata: metadata}
+	assert.NoError(t, cert.VerifyMetadata(data))
+
+	changed := []byte(`{"name":"Example Pool","description":"An example stake pool","ticker":"EXMP","homepage":"https://example.com"}`)
+	assert.ErrorIs(t, cert.VerifyMetadata(changed), tx.ErrPoolMetadataHashMismatch)
+
+	_, err = tx.ParsePoolMetadata([]byte(`{"name":"Example Pool","ticker":"EXAMPLE"}`))
+	assert.ErrorIs(t, err, tx.ErrInvalidPoolMetadataField)
+
+	large := []byte(`{"name":"Example Pool","ticker":"EXMPL","description":"` + strings.Repeat("a", 500) + `"}`)
+	_, err = tx.ParsePoolMetadata(large)
+	assert.ErrorIs(t, err, tx.ErrPoolMetadataTooLarge)
+
+	assert.ErrorIs(t, (&tx.PoolRegistration{}).VerifyMetadata(data), tx.ErrMissingPoolMetadata)
+}
+
+func TestConwayCertificateEncoding(t *testing.T) {
+	hash := bytes.Repeat([]byte{0x02}, 28)
+	pool, _ := crypto.Ed25519KeyHashFromBytes(bytes.Repeat([]byte{0x01}, 28))
+	dataHash, _ := crypto.AnchorDataHashFromBytes(bytes.Repeat([]byte{0x03}, 32))
+	keyCred := address.NewKeyStakeCredential(hash)
+	scriptCred := address.NewScriptStakeCredential(hash)
+
+	anchor, err := tx.NewAnchor("https://a.b", dataHash)
+	assert.NoError(t, err)
+	_, err = tx.NewAnchor("https://"+strings.Repeat("a", 128), dataHash)
+	assert.ErrorIs(t, err, tx.ErrInvalidAnchor)
+
+	hashHex := hex.EncodeToString(hash)
+	scenarios := []struct {
+		description string
+		cert        tx.Certificate
+		decoded     tx.Certificate
+		cborHex     string
+	}{
+		{
+			description: "vote delegation to always abstain",
+			cert:        tx.NewVoteDelegation(keyCred, tx.NewAlwaysAbstainDRep()),
+			decoded:     &tx.VoteDelegation{},
+			cborHex:     "83098200581c" + hashHex + "8102",
+		},
+		{
+			description: "stake and vote registration delegation",
+			cert:        tx.NewStakeVoteRegistrationDelegation(keyCred, pool, tx.NewDRep(keyCred), 2000000),
+			decoded:     &tx.StakeVoteRegistrationDelegation{},
+			cborHex:     "850d8200581c" + hashHex + "581c" + hex.EncodeToString(pool[:]) + "8200581c" + hashHex + "1a001e8480",
+		},
+		{
+			description: "drep registration with anchor",
+			cert:        tx.NewDRepRegistration(keyCred, 500000000, anchor),
+			decoded:     &tx.DRepRegistration{},
+			cborHex:     "84108200581c" + hashHex + "1a1dcd6500826b" + hex.EncodeToString([]byte("https://a.b")) + "5820" + hex.EncodeToString(dataHash[:]),
+		},
+		{
+			description: "drep update without anchor",
+			cert:        tx.NewDRepUpdate(keyCred, nil),
+			decoded:     &tx.DRepUpdate{},
+			cborHex:     "83128200581c" + hashHex + "f6",
+		},
+		{
+			description: "committee hot key authorization",
+			cert:        tx.NewCommitteeHotAuth(keyCred, scriptCred),
+			decoded:     &tx.CommitteeHotAuth{},
+			cborHex:     "830e8200581c" + hashHex + "8201581c" + hashHex,
+		},
+		{
+			description: "shelley stake registration",
+			cert:        tx.NewStakeRegistration(keyCred),
+			decoded:     &tx.StakeRegistration{},
+			cborHex:     "82008200581c" + hashHex,
+		},
+	}
+
+	for _, sc := range scenarios {
+		t.Run(sc.description, func(t *testing.T) {
+			data, err := sc.cert.MarshalCBOR()
+			assert.NoError(t, err)
+			assert.Equal(t, sc.cborHex, hex.EncodeToString(data))
+			assert.NoError(t, cbor.Unmarshal(data, sc.decoded))
+			assert.Equal(t, sc.cert, sc.decoded)
+
+			body := &tx.TxBody{Certificates: []tx.Certificate{sc.cert}}
+			data, err = body.Bytes()
+			assert.NoError(t, err)
+			decoded := &tx.TxBody{}
+			assert.NoError(t, cbor.Unmarshal(data, decoded))
+			assert.Equal(t, body.Certificates, decoded.Certificates)
+		})
+	}
+}
+
+func TestTxBuilderGovernanceDeposits(t *testing.T) {
+	rootKey := createRootKey()
+	addr, utxoPrv, err := generateBaseAddress(network.TestNet())
+	if err != nil {
+		t.Fatal(err)
+	}
+	accountKey := rootKey.Derive(harden(1852)).Derive(harden(1815)).Derive(harden(0))
+	stakePrv := accountKey.Derive(2).Derive(0)
+	drepPrv := accountKey.Derive(3).Derive(0)
+	drepHash := drepPrv.Public().PublicKey().Hash()
+	drepCred := address.NewKeyStakeCredential(drepHash[:])
+
+	pr := protocol.Protocol{TxFeePerByte: 44, TxFeeFixed: 155381, KeyDeposit: 2000000, DRepDeposit: 500000000}
+	newBuilder := func(certs ...tx.Certificate) *tx.TxBuilder {
+		builder := tx.NewTxBuilder(pr, []bip32.XPrv{utxoPrv, stakePrv, drepPrv})
+		builder.AddInputs(tx.NewTxInput("fcbc18c64cdf133f33dd319c5105dc7c4972f2d646ae276fbd00cf7f39f8c380", 0, 1000000000))
+		builder.AddCertificates(certs...)
+		builder.AddChangeIfNeeded(addr)
+		return builder
+	}
+
+	builder := newBuilder(
+		tx.NewStakeVoteRegistrationDelegation(&addr.Stake, crypto.Ed25519KeyHash{}, tx.NewDRep(drepCred), 2000000),
+		tx.NewDRepRegistration(drepCred, 500000000, nil),
+	)
+	txBody := builder.Tx().Body
+	assert.Equal(t, uint(1000000000-502000000)-uint(txBody.Fee), txBody.Outputs[0].Amount)
+	_, err = builder.Build()
+	assert.NoError(t, err)
+
+	builder = newBuilder(
+		tx.NewStakeDeregistration(&addr.Stake),
+		tx.NewDRepDeregistration(drepCred, 500000000),
+	)
+	txBody = builder.Tx().Body
+	assert.Equal(t, uint(1000000000+502000000)-uint(txBody.Fee), txBody.Outputs[0].Amount)
+
+	_, err = newBuilder(tx.NewDRepRegistration(drepCred, 1000000, nil)).Build()
+	assert.ErrorIs(t, err, tx.ErrInvalidDeposit)
+
+	_, err = newBuilder(tx.NewDRepDeregistration(drepCred, 1000000)).Build()
+	assert.ErrorIs(t, err, tx.ErrInvalidRefund)
+	_, err = newBuilder(tx.NewStakeDeregistrationWithRefund(&addr.Stake, 1000000)).Build()
+	assert.ErrorIs(t, err, tx.ErrInvalidRefund)
+	_, err = newBuilder(tx.NewStakeDeregistrationWithRefund(&addr.Stake, 2000000)).Build()
+	assert.NoError(t, err)
+
+	// Without protocol deposits, refunds are checked against registrations in the same transaction.
+	pr = protocol.Protocol{TxFeePerByte: 44, TxFeeFixed: 155381}
+	_, err = newBuilder(
+		tx.NewStakeRegistrationWithDeposit(&addr.Stake, 3000000),
+		tx.NewStakeDeregistrationWithRefund(&addr.Stake, 4000000),
+	).Build()
+	assert.ErrorIs(t, err, tx.ErrInvalidRefund)
+	_, err = newBuilder(
+		tx.NewDRepRegistration(drepCred, 3000000, nil),
+		tx.NewDRepDeregistration(drepCred, 3000000),
+	).Build()
+	assert.NoError(t, err)
+	_, err = newBuilder(tx.NewDRepDeregistration(drepCred, 1000000)).Build()
+	assert.NoError(t, err)
+}
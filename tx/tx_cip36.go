@@ -0,0 +1,230 @@
+package tx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+)
+
+// Metadata labels of CIP-36 vote key registrations.
+const (
+	CIP36RegistrationLabel = 61284
+	CIP36WitnessLabel      = 61285
+)
+
+// CIP36CatalystPurpose is the voting purpose of Catalyst registrations.
+const CIP36CatalystPurpose = 0
+
+var (
+	ErrInvalidCIP36Registration = errors.New("invalid cip-36 registration")
+	ErrInvalidCIP36Signature    = errors.New("cip-36 registration signature does not match the stake key")
+	ErrMissingCIP36Registration = errors.New("transaction metadata has no cip-36 registration")
+)
+
+// CIP36Delegation delegates a weighted share of the voting power to a vote key.
+type CIP36Delegation struct {
+	VoteKey bip32.PublicKey
+	Weight  uint32
+}
+
+// CIP36Registration registers the vote keys the voting power of a stake key is delegated to.
+// Rewards are paid to RewardAddress, which may be any address type.
+type CIP36Registration struct {
+	Delegations   []CIP36Delegation
+	StakeKey      bip32.PublicKey
+	RewardAddress address.Address
+	Nonce         uint64
+	Purpose       uint64
+}
+
+// NewCIP36Registration returns a pointer to a new Catalyst CIP36Registration. The nonce is usually the current slot
+// so that later registrations supersede earlier ones.
+func NewCIP36Registration(delegations []CIP36Delegation, stakeKey bip32.PublicKey, rewardAddress address.Address, nonce uint64) *CIP36Registration {
+	return &CIP36Registration{
+		Delegations:   delegations,
+		StakeKey:      stakeKey,
+		RewardAddress: rewardAddress,
+		Nonce:         nonce,
+		Purpose:       CIP36CatalystPurpose,
+	}
+}
+
+// Validate checks the keys and delegations of the registration.
+func (r *CIP36Registration) Validate() error {
+	if len(r.Delegations) == 0 {
+		return fmt.Errorf("%w: at least one delegation is required", ErrInvalidCIP36Registration)
+	}
+	for i, delegation := range r.Delegations {
+		if len(delegation.VoteKey) != crypto.PublicKeyLen {
+			return fmt.Errorf("%w: vote key of delegation %d must be %d bytes", ErrInvalidCIP36Registration, i, crypto.PublicKeyLen)
+		}
+	}
+	if len(r.StakeKey) != crypto.PublicKeyLen {
+		return fmt.Errorf("%w: stake key must be %d bytes", ErrInvalidCIP36Registration, crypto.PublicKeyLen)
+	}
+	if r.RewardAddress == nil {
+		return fmt.Errorf("%w: reward address is required", ErrInvalidCIP36Registration)
+	}
+	return nil
+}
+
+// metadata returns the label 61284 metadata of the registration.
+func (r *CIP36Registration) metadata() (TransactionMetadata, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	delegations := NewListMetadatum()
+	for _, delegation := range r.Delegations {
+		delegations.List = append(delegations.List, NewListMetadatum(
+			NewBytesMetadatum(delegation.VoteKey),
+			NewIntMetadatum(int64(delegation.Weight)),
+		))
+	}
+
+	registration := NewMapMetadatum(
+		MetadatumEntry{Key: NewIntMetadatum(1), Value: delegations},
+		MetadatumEntry{Key: NewIntMetadatum(2), Value: NewBytesMetadatum(r.StakeKey)},
+		MetadatumEntry{Key: NewIntMetadatum(3), Value: NewBytesMetadatum(r.RewardAddress.Bytes())},
+		MetadatumEntry{Key: NewIntMetadatum(4), Value: &TransactionMetadatum{Kind: IntMetadatum, Int: new(big.Int).SetUint64(r.Nonce)}},
+		MetadatumEntry{Key: NewIntMetadatum(5), Value: &TransactionMetadatum{Kind: IntMetadatum, Int: new(big.Int).SetUint64(r.Purpose)}},
+	)
+	return TransactionMetadata{CIP36RegistrationLabel: registration}, nil
+}
+
+// cip36Hash returns the blake2b256 hash of the label 61284 metadata which is signed by the stake key.
+func cip36Hash(metadata TransactionMetadata) ([]byte, error) {
+	data, err := TransactionMetadata{CIP36RegistrationLabel: metadata[CIP36RegistrationLabel]}.MarshalCBOR()
+	if err != nil {
+		return nil, err
+	}
+	hash := crypto.Blake2b256(data)
+	return hash[:], nil
+}
+
+// Sign returns the registration and its witness signed by the stake key as labels 61284 and 61285.
+func (r *CIP36Registration) Sign(stakeKey bip32.XPrv) (TransactionMetadata, error) {
+	if !bytes.Equal(stakeKey.Public().PublicKey(), r.StakeKey) {
+		return nil, ErrInvalidCIP36Signature
+	}
+
+	metadata, err := r.metadata()
+	if err != nil {
+		return nil, err
+	}
+	hash, err := cip36Hash(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	signature := stakeKey.Sign(hash)
+	metadata[CIP36WitnessLabel] = NewMapMetadatum(
+		MetadatumEntry{Key: NewIntMetadatum(1), Value: NewBytesMetadatum(signature[:])},
+	)
+	return metadata, nil
+}
+
+// metadatumUint returns the value of an unsigned integer metadatum.
+func metadatumUint(m *TransactionMetadatum) (uint64, bool) {
+	if m.Kind != IntMetadatum || m.Int.Sign() < 0 || !m.Int.IsUint64() {
+		return 0, false
+	}
+	return m.Int.Uint64(), true
+}
+
+// parseCIP36Delegations returns the delegations of a registration, either a list of weighted
+// vote keys or a single CIP-15 vote key.
+func parseCIP36Delegations(m *TransactionMetadatum) ([]CIP36Delegation, error) {
+	if m.Kind == BytesMetadatum {
+		return []CIP36Delegation{{VoteKey: m.Bytes, Weight: 1}}, nil
+	}
+	if m.Kind != ListMetadatum {
+		return nil, ErrInvalidCIP36Registration
+	}
+
+	var delegations []CIP36Delegation
+	for _, item := range m.List {
+		if item.Kind != ListMetadatum || len(item.List) != 2 || item.List[0].Kind != BytesMetadatum {
+			return nil, ErrInvalidCIP36Registration
+		}
+		weight, ok := metadatumUint(item.List[1])
+		if !ok || weight > 0xffffffff {
+			return nil, ErrInvalidCIP36Registration
+		}
+		delegations = append(delegations, CIP36Delegation{VoteKey: item.List[0].Bytes, Weight: uint32(weight)})
+	}
+	return delegations, nil
+}
+
+// VerifyCIP36Registration returns the registration of the metadata after verifying its witness
+// was signed by the registered stake key.
+func VerifyCIP36Registration(metadata TransactionMetadata) (*CIP36Registration, error) {
+	registration, ok := metadata[CIP36RegistrationLabel]
+	witness, hasWitness := metadata[CIP36WitnessLabel]
+	if !ok || !hasWitness {
+		return nil, ErrMissingCIP36Registration
+	}
+	if registration.Kind != MapMetadatum || witness.Kind != MapMetadatum {
+		return nil, ErrInvalidCIP36Registration
+	}
+
+	r := &CIP36Registration{Purpose: CIP36CatalystPurpose}
+	for _, entry := range registration.Map {
+		key, ok := metadatumUint(entry.Key)
+		if !ok {
+			continue
+		}
+
+		var err error
+		switch key {
+		case 1:
+			r.Delegations, err = parseCIP36Delegations(entry.Value)
+		case 2:
+			if entry.Value.Kind != BytesMetadatum {
+				return nil, ErrInvalidCIP36Registration
+			}
+			r.StakeKey = entry.Value.Bytes
+		case 3:
+			if entry.Value.Kind != BytesMetadatum || len(entry.Value.Bytes) == 0 {
+				return nil, ErrInvalidCIP36Registration
+			}
+			r.RewardAddress, err = address.NewAddressFromBytes(entry.Value.Bytes)
+		case 4:
+			if r.Nonce, ok = metadatumUint(entry.Value); !ok {
+				return nil, ErrInvalidCIP36Registration
+			}
+		case 5:
+			if r.Purpose, ok = metadatumUint(entry.Value); !ok {
+				return nil, ErrInvalidCIP36Registration
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCIP36Registration, err)
+		}
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	var signature []byte
+	for _, entry := range witness.Map {
+		if key, ok := metadatumUint(entry.Key); ok && key == 1 && entry.Value.Kind == BytesMetadatum {
+			signature = entry.Value.Bytes
+		}
+	}
+
+	hash, err := cip36Hash(metadata)
+	if err != nil {
+		return nil, err
+	}
+	if !r.StakeKey.Verify(hash, signature) {
+		return nil, ErrInvalidCIP36Signature
+	}
+
+	return r, nil
+}
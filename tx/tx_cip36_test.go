@@ -0,0 +1,104 @@
+package tx_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fivebinaries/go-cardano-serialization/network"
+	"github.com/fivebinaries/go-cardano-serialization/protocol"
+	"github.com/fivebinaries/go-cardano-serialization/tx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCIP36Registration(t *testing.T) {
+	addr, utxoPrv, err := generateBaseAddress(network.TestNet())
+	if err != nil {
+		t.Fatal(err)
+	}
+	accountKey := createRootKey().Derive(harden(1852)).Derive(harden(1815)).Derive(harden(0))
+	stakeKey := accountKey.Derive(2).Derive(0)
+	voteKey1, _ := hex.DecodeString("0036ef3e1f0d3f5989e2d155ea54bdb2a72c4c456ccb959af4c94868f473f5a0")
+	voteKey2, _ := hex.DecodeString("1e3cd2404c84de65f96918f18d5b445bcb933a7cda18eeded7945dd191e43236")
+
+	registration := tx.NewCIP36Registration([]tx.CIP36Delegation{
+		{VoteKey: voteKey1, Weight: 1},
+		{VoteKey: voteKey2, Weight: 3},
+	}, stakeKey.Public().PublicKey(), addr, 1234)
+
+	metadata, err := registration.Sign(stakeKey)
+	assert.NoError(t, err)
+	data, err := tx.TransactionMetadata{tx.CIP36RegistrationLabel: metadata[tx.CIP36RegistrationLabel]}.MarshalCBOR()
+	assert.NoError(t, err)
+	assert.Equal(t, "a119ef64a5018282582"+"00036ef3e1f0d3f5989e2d155ea54bdb2a72c4c456ccb959af4c94868f473f5a001"+
+		"8258201e3cd2404c84de65f96918f18d5b445bcb933a7cda18eeded7945dd191e4323603"+
+		"025820"+hex.EncodeToString(stakeKey.Public().PublicKey())+
+		"035839"+hex.EncodeToString(addr.Bytes())+"041904d20500", hex.EncodeToString(data))
+
+	// The registration round trips through the cbor encoding of the transaction metadata.
+	data, err = metadata.MarshalCBOR()
+	assert.NoError(t, err)
+	decoded := tx.TransactionMetadata{}
+	assert.NoError(t, decoded.UnmarshalCBOR(data))
+	verified, err := tx.VerifyCIP36Registration(decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, registration.Delegations, verified.Delegations)
+	assert.Equal(t, registration.StakeKey, verified.StakeKey)
+	assert.Equal(t, addr.String(), verified.RewardAddress.String())
+	assert.Equal(t, uint64(1234), verified.Nonce)
+	assert.Equal(t, uint64(tx.CIP36CatalystPurpose), verified.Purpose)
+
+	_, err = registration.Sign(utxoPrv)
+	assert.ErrorIs(t, err, tx.ErrInvalidCIP36Signature)
+	_, err = tx.NewCIP36Registration(nil, stakeKey.Public().PublicKey(), addr, 1234).Sign(stakeKey)
+	assert.ErrorIs(t, err, tx.ErrInvalidCIP36Registration)
+	_, err = tx.VerifyCIP36Registration(tx.NewMessageMetadata("hello"))
+	assert.ErrorIs(t, err, tx.ErrMissingCIP36Registration)
+
+	// Changing the nonce invalidates the witness.
+	decoded[tx.CIP36RegistrationLabel].Map[3].Value = tx.NewIntMetadatum(1235)
+	_, err = tx.VerifyCIP36Registration(decoded)
+	assert.ErrorIs(t, err, tx.ErrInvalidCIP36Signature)
+
+	// CIP-15 registrations with a single vote key are delegations of weight 1.
+	legacy := tx.NewCIP36Registration([]tx.CIP36Delegation{{VoteKey: voteKey1, Weight: 1}}, stakeKey.Public().PublicKey(), addr, 1)
+	metadata, err = legacy.Sign(stakeKey)
+	assert.NoError(t, err)
+	metadata[tx.CIP36RegistrationLabel].Map[0].Value = tx.NewBytesMetadatum(voteKey1)
+	_, err = tx.VerifyCIP36Registration(metadata)
+	assert.ErrorIs(t, err, tx.ErrInvalidCIP36Signature)
+
+	data, err = tx.TransactionMetadata{tx.CIP36RegistrationLabel: metadata[tx.CIP36RegistrationLabel]}.MarshalCBOR()
+	assert.NoError(t, err)
+	hash := crypto.Blake2b256(data)
+	signature := stakeKey.Sign(hash[:])
+	metadata[tx.CIP36WitnessLabel].Map[0].Value = tx.NewBytesMetadatum(signature[:])
+	verified, err = tx.VerifyCIP36Registration(metadata)
+	assert.NoError(t, err)
+	assert.Equal(t, legacy.Delegations, verified.Delegations)
+
+	builder := tx.NewTxBuilder(protocol.Protocol{TxFeePerByte: 44, TxFeeFixed: 155381}, []bip32.XPrv{utxoPrv})
+	builder.AddInputs(tx.NewTxInput("fcbc18c64cdf133f33dd319c5105dc7c4972f2d646ae276fbd00cf7f39f8c380", 0, 1000000000))
+	assert.NoError(t, builder.SetMessage("Catalyst registration"))
+	assert.NoError(t, builder.SetCIP36Registration(registration, stakeKey))
+	builder.AddChangeIfNeeded(addr)
+	txFinal, err := builder.Build()
+	assert.NoError(t, err)
+
+	data, err = txFinal.Bytes()
+	assert.NoError(t, err)
+	aux, err := tx.NewAuxiliaryDataFromTxBytes(data)
+	assert.NoError(t, err)
+	_, err = tx.VerifyCIP36Registration(aux.Metadata)
+	assert.NoError(t, err)
+	lines, err := aux.Metadata.Message()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Catalyst registration"}, lines)
+
+	// Registrations may pay rewards to any address type.
+	reward := address.NewEnterpriseAddress(network.TestNet(), &addr.Payment)
+	_, err = tx.NewCIP36Registration(registration.Delegations, stakeKey.Public().PublicKey(), reward, 1).Sign(stakeKey)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,211 @@
+package tx
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// CIP-67 asset name labels of CIP-68 tokens.
+const (
+	CIP68ReferenceLabel uint16 = 100
+	CIP68NFTLabel       uint16 = 222
+	CIP68FTLabel        uint16 = 333
+	CIP68RFTLabel       uint16 = 444
+)
+
+const (
+	// CIP68Version is the version of the metadata datum.
+	CIP68Version = 1
+
+	cip67PrefixLen = 4
+
+	// plutusConstr0Tag is the cbor tag of the first constructor of plutus data.
+	plutusConstr0Tag = 121
+)
+
+var (
+	ErrInvalidCIP67Label = errors.New("invalid cip-67 asset name label")
+)
+
+// crc8 returns the CRC-8 checksum with polynomial 0x07 used by CIP-67 labels.
+func crc8(data []byte) byte {
+	var crc byte
+	for _, b := range data {
+		crc ^= b
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// NewCIP67AssetName returns the asset name of the content prefixed with the CIP-67 label.
+func NewCIP67AssetName(label uint16, content []byte) (AssetName, error) {
+	var labelBytes [2]byte
+	binary.BigEndian.PutUint16(labelBytes[:], label)
+
+	prefix := uint32(label)<<12 | uint32(crc8(labelBytes[:]))<<4
+	name := make([]byte, cip67PrefixLen, cip67PrefixLen+len(content))
+	binary.BigEndian.PutUint32(name, prefix)
+
+	return NewAssetName(append(name, content...))
+}
+
+// ParseCIP67AssetName returns the label and content of an asset name prefixed with a CIP-67 label.
+func ParseCIP67AssetName(name AssetName) (label uint16, content []byte, err error) {
+	if len(name) < cip67PrefixLen {
+		return 0, nil, ErrInvalidCIP67Label
+	}
+
+	prefix := binary.BigEndian.Uint32(name)
+	if prefix>>28 != 0 || prefix&0xf != 0 {
+		return 0, nil, ErrInvalidCIP67Label
+	}
+
+	label = uint16(prefix >> 12)
+	var labelBytes [2]byte
+	binary.BigEndian.PutUint16(labelBytes[:], label)
+	if crc8(labelBytes[:]) != byte(prefix>>4) {
+		return 0, nil, ErrInvalidCIP67Label
+	}
+
+	return label, name[cip67PrefixLen:], nil
+}
+
+// CIP68Datum is the inline datum of a CIP-68 reference token, `Constr 0 [metadata, version, extra]`.
+type CIP68Datum struct {
+	Metadata *TransactionMetadatum
+	Version  uint64
+}
+
+// MarshalCBOR returns the plutus data encoding of the datum. Text metadata is encoded as utf-8 bytes
+// and the extra field is the unit constructor.
+func (d *CIP68Datum) MarshalCBOR() ([]byte, error) {
+	if d.Metadata == nil || d.Metadata.Kind != MapMetadatum {
+		return nil, fmt.Errorf("%w: metadata must be a map", ErrInvalidNFTMetadata)
+	}
+
+	metadata, err := plutusData(d.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	version, err := cbor.Marshal(d.Version)
+	if err != nil {
+		return nil, err
+	}
+	extra, err := cbor.Marshal(cbor.Tag{Number: plutusConstr0Tag, Content: []interface{}{}})
+	if err != nil {
+		return nil, err
+	}
+
+	fields := append(cborHeader(4, 3), metadata...)
+	fields = append(fields, version...)
+	fields = append(fields, extra...)
+	return cbor.Marshal(cbor.Tag{Number: plutusConstr0Tag, Content: cbor.RawMessage(fields)})
+}
+
+// plutusData returns the plutus data encoding of the metadatum. Byte strings longer than 64 bytes
+// are encoded as indefinite length byte strings of 64 byte chunks.
+func plutusData(m *TransactionMetadatum) ([]byte, error) {
+	if m == nil {
+		return nil, ErrInvalidMetadatum
+	}
+
+	switch m.Kind {
+	case IntMetadatum:
+		return cbor.Marshal(m.Int)
+	case BytesMetadatum, TextMetadatum:
+		b := m.Bytes
+		if m.Kind == TextMetadatum {
+			b = []byte(m.Text)
+		}
+		if len(b) <= MetadatumMaxLen {
+			return cbor.Marshal(b)
+		}
+		out := []byte{0x5f}
+		for len(b) > 0 {
+			n := len(b)
+			if n > MetadatumMaxLen {
+				n = MetadatumMaxLen
+			}
+			chunk, err := cbor.Marshal(b[:n])
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, chunk...)
+			b = b[n:]
+		}
+		return append(out, 0xff), nil
+	case ListMetadatum:
+		out := cborHeader(4, uint64(len(m.List)))
+		for _, item := range m.List {
+			data, err := plutusData(item)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, data...)
+		}
+		return out, nil
+	case MapMetadatum:
+		out := cborHeader(5, uint64(len(m.Map)))
+		for _, entry := range m.Map {
+			key, err := plutusData(entry.Key)
+			if err != nil {
+				return nil, err
+			}
+			value, err := plutusData(entry.Value)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, key...)
+			out = append(out, value...)
+		}
+		return out, nil
+	}
+	return nil, ErrInvalidMetadatum
+}
+
+// CIP68Token is the pair of reference and user tokens of a CIP-68 asset. The reference token is sent
+// to an output holding the datum inline, the user token to its owner.
+type CIP68Token struct {
+	Reference AssetName
+	User      AssetName
+	Datum     *CIP68Datum
+}
+
+// NewCIP68NFT returns a pointer to the CIP68Token pair of an NFT with the name and metadata.
+// Texts of the metadata are stored as utf-8 byte strings without being split into lists.
+func NewCIP68NFT(name []byte, metadata *NFTMetadata) (*CIP68Token, error) {
+	if err := metadata.Validate(); err != nil {
+		return nil, err
+	}
+
+	reference, err := NewCIP67AssetName(CIP68ReferenceLabel, name)
+	if err != nil {
+		return nil, err
+	}
+	user, err := NewCIP67AssetName(CIP68NFTLabel, name)
+	if err != nil {
+		return nil, err
+	}
+
+	key := func(k string) *TransactionMetadatum {
+		return &TransactionMetadatum{Kind: BytesMetadatum, Bytes: []byte(k)}
+	}
+	text := func(s string) *TransactionMetadatum {
+		return &TransactionMetadatum{Kind: TextMetadatum, Text: s}
+	}
+
+	return &CIP68Token{
+		Reference: reference,
+		User:      user,
+		Datum:     &CIP68Datum{Metadata: metadata.metadatum(key, text), Version: CIP68Version},
+	}, nil
+}
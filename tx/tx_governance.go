@@ -0,0 +1,294 @@
+package tx
+
+import (
+	"errors"
+
+	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fxamacker/cbor/v2"
+)
+
+const maxAnchorURLLen = 128
+
+var (
+	ErrInvalidAnchor = errors.New("invalid anchor")
+	ErrInvalidDRep   = errors.New("invalid drep")
+)
+
+// Anchor references off-chain governance content, e.g. drep metadata, by url and the hash of the content.
+type Anchor struct {
+	URL      string
+	DataHash crypto.AnchorDataHash
+}
+
+// NewAnchor returns a pointer to a new Anchor given the url and the hash of the content.
+func NewAnchor(url string, dataHash crypto.AnchorDataHash) (*Anchor, error) {
+	if len(url) > maxAnchorURLLen {
+		return nil, ErrInvalidAnchor
+	}
+	return &Anchor{URL: url, DataHash: dataHash}, nil
+}
+
+// MarshalCBOR returns the cbor encoding of the anchor.
+func (a *Anchor) MarshalCBOR() ([]byte, error) {
+	if len(a.URL) > maxAnchorURLLen {
+		return nil, ErrInvalidAnchor
+	}
+	return cbor.Marshal([]interface{}{a.URL, a.DataHash[:]})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded anchor.
+func (a *Anchor) UnmarshalCBOR(data []byte) error {
+	anchor := Anchor{}
+	if err := unmarshalArray(data, ErrInvalidAnchor, &anchor.URL, fixed(anchor.DataHash[:])); err != nil {
+		return err
+	}
+	if len(anchor.URL) > maxAnchorURLLen {
+		return ErrInvalidAnchor
+	}
+	*a = anchor
+	return nil
+}
+
+// optionalAnchor returns nil for a missing anchor so it is encoded as cbor null.
+func optionalAnchor(a *Anchor) interface{} {
+	if a == nil {
+		return nil
+	}
+	return a
+}
+
+// DRepType is the tag identifying the kind of a delegate representative in its cbor encoding.
+type DRepType uint
+
+const (
+	KeyHashDRepType DRepType = iota
+	ScriptHashDRepType
+	AlwaysAbstainDRepType
+	AlwaysNoConfidenceDRepType
+)
+
+// DRep is the target of a vote delegation. It is either a registered delegate representative
+// identified by its credential or one of the predefined abstain and no confidence options.
+type DRep struct {
+	Kind DRepType
+	Hash []byte
+}
+
+// NewDRep returns a pointer to the DRep registered with the credential.
+func NewDRep(cred *address.StakeCredential) *DRep {
+	if cred.Kind == address.ScriptStakeCredentialType {
+		return &DRep{Kind: ScriptHashDRepType, Hash: cred.Payload}
+	}
+	return &DRep{Kind: KeyHashDRepType, Hash: cred.Payload}
+}
+
+// NewAlwaysAbstainDRep returns a pointer to the predefined DRep abstaining from every vote.
+func NewAlwaysAbstainDRep() *DRep {
+	return &DRep{Kind: AlwaysAbstainDRepType}
+}
+
+// NewAlwaysNoConfidenceDRep returns a pointer to the predefined DRep voting no confidence on every vote.
+func NewAlwaysNoConfidenceDRep() *DRep {
+	return &DRep{Kind: AlwaysNoConfidenceDRepType}
+}
+
+// MarshalCBOR returns the cbor encoding of the drep.
+func (d *DRep) MarshalCBOR() ([]byte, error) {
+	switch d.Kind {
+	case KeyHashDRepType, ScriptHashDRepType:
+		if len(d.Hash) != crypto.Ed25519KeyHashLen {
+			return nil, ErrInvalidDRep
+		}
+		return cbor.Marshal([]interface{}{d.Kind, d.Hash})
+	case AlwaysAbstainDRepType, AlwaysNoConfidenceDRepType:
+		return cbor.Marshal([]interface{}{d.Kind})
+	default:
+		return nil, ErrInvalidDRep
+	}
+}
+
+// UnmarshalCBOR deserializes a cbor encoded drep.
+func (d *DRep) UnmarshalCBOR(data []byte) error {
+	var raw []cbor.RawMessage
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) == 0 {
+		return ErrInvalidDRep
+	}
+
+	drep := DRep{}
+	if err := cbor.Unmarshal(raw[0], &drep.Kind); err != nil {
+		return err
+	}
+	switch {
+	case (drep.Kind == KeyHashDRepType || drep.Kind == ScriptHashDRepType) && len(raw) == 2:
+		drep.Hash = make([]byte, crypto.Ed25519KeyHashLen)
+		if err := cbor.Unmarshal(raw[1], fixed(drep.Hash)); err != nil {
+			return err
+		}
+	case (drep.Kind == AlwaysAbstainDRepType || drep.Kind == AlwaysNoConfidenceDRepType) && len(raw) == 1:
+	default:
+		return ErrInvalidDRep
+	}
+
+	*d = drep
+	return nil
+}
+
+// CommitteeHotAuth is a certificate authorizing a hot credential to vote on behalf of a constitutional committee member.
+type CommitteeHotAuth struct {
+	ColdCredential address.StakeCredential
+	HotCredential  address.StakeCredential
+}
+
+// NewCommitteeHotAuth returns a pointer to a new CommitteeHotAuth certificate.
+func NewCommitteeHotAuth(cold, hot *address.StakeCredential) *CommitteeHotAuth {
+	return &CommitteeHotAuth{ColdCredential: *cold, HotCredential: *hot}
+}
+
+// Type returns CommitteeHotAuthCertificateType.
+func (c *CommitteeHotAuth) Type() CertificateType {
+	return CommitteeHotAuthCertificateType
+}
+
+// MarshalCBOR returns the cbor encoding of the committee hot key authorization certificate.
+func (c *CommitteeHotAuth) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{c.Type(), c.ColdCredential, c.HotCredential})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded committee hot key authorization certificate.
+func (c *CommitteeHotAuth) UnmarshalCBOR(data []byte) error {
+	cert := CommitteeHotAuth{}
+	if err := unmarshalTypedArray(data, uint(CommitteeHotAuthCertificateType), ErrInvalidCertificate, &cert.ColdCredential, &cert.HotCredential); err != nil {
+		return err
+	}
+	*c = cert
+	return nil
+}
+
+// CommitteeColdResign is a certificate resigning a constitutional committee member, optionally anchoring a rationale.
+type CommitteeColdResign struct {
+	ColdCredential address.StakeCredential
+	Anchor         *Anchor
+}
+
+// NewCommitteeColdResign returns a pointer to a new CommitteeColdResign certificate. The anchor may be nil.
+func NewCommitteeColdResign(cold *address.StakeCredential, anchor *Anchor) *CommitteeColdResign {
+	return &CommitteeColdResign{ColdCredential: *cold, Anchor: anchor}
+}
+
+// Type returns CommitteeColdResignCertificateType.
+func (c *CommitteeColdResign) Type() CertificateType {
+	return CommitteeColdResignCertificateType
+}
+
+// MarshalCBOR returns the cbor encoding of the committee resignation certificate.
+func (c *CommitteeColdResign) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{c.Type(), c.ColdCredential, optionalAnchor(c.Anchor)})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded committee resignation certificate.
+func (c *CommitteeColdResign) UnmarshalCBOR(data []byte) error {
+	cert := CommitteeColdResign{}
+	if err := unmarshalTypedArray(data, uint(CommitteeColdResignCertificateType), ErrInvalidCertificate, &cert.ColdCredential, &cert.Anchor); err != nil {
+		return err
+	}
+	*c = cert
+	return nil
+}
+
+// DRepRegistration is a certificate registering a delegate representative, optionally anchoring its metadata.
+type DRepRegistration struct {
+	Credential address.StakeCredential
+	Deposit    uint64
+	Anchor     *Anchor
+}
+
+// NewDRepRegistration returns a pointer to a new DRepRegistration certificate. The anchor may be nil.
+func NewDRepRegistration(cred *address.StakeCredential, deposit uint64, anchor *Anchor) *DRepRegistration {
+	return &DRepRegistration{Credential: *cred, Deposit: deposit, Anchor: anchor}
+}
+
+// Type returns DRepRegistrationCertificateType.
+func (d *DRepRegistration) Type() CertificateType {
+	return DRepRegistrationCertificateType
+}
+
+// MarshalCBOR returns the cbor encoding of the drep registration certificate.
+func (d *DRepRegistration) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{d.Type(), d.Credential, d.Deposit, optionalAnchor(d.Anchor)})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded drep registration certificate.
+func (d *DRepRegistration) UnmarshalCBOR(data []byte) error {
+	cert := DRepRegistration{}
+	if err := unmarshalTypedArray(data, uint(DRepRegistrationCertificateType), ErrInvalidCertificate, &cert.Credential, &cert.Deposit, &cert.Anchor); err != nil {
+		return err
+	}
+	*d = cert
+	return nil
+}
+
+// DRepDeregistration is a certificate retiring a delegate representative and refunding its deposit.
+type DRepDeregistration struct {
+	Credential address.StakeCredential
+	Refund     uint64
+}
+
+// NewDRepDeregistration returns a pointer to a new DRepDeregistration certificate.
+func NewDRepDeregistration(cred *address.StakeCredential, refund uint64) *DRepDeregistration {
+	return &DRepDeregistration{Credential: *cred, Refund: refund}
+}
+
+// Type returns DRepDeregistrationCertificateType.
+func (d *DRepDeregistration) Type() CertificateType {
+	return DRepDeregistrationCertificateType
+}
+
+// MarshalCBOR returns the cbor encoding of the drep deregistration certificate.
+func (d *DRepDeregistration) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{d.Type(), d.Credential, d.Refund})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded drep deregistration certificate.
+func (d *DRepDeregistration) UnmarshalCBOR(data []byte) error {
+	cert := DRepDeregistration{}
+	if err := unmarshalTypedArray(data, uint(DRepDeregistrationCertificateType), ErrInvalidCertificate, &cert.Credential, &cert.Refund); err != nil {
+		return err
+	}
+	*d = cert
+	return nil
+}
+
+// DRepUpdate is a certificate updating the metadata anchor of a delegate representative.
+type DRepUpdate struct {
+	Credential address.StakeCredential
+	Anchor     *Anchor
+}
+
+// NewDRepUpdate returns a pointer to a new DRepUpdate certificate. The anchor may be nil.
+func NewDRepUpdate(cred *address.StakeCredential, anchor *Anchor) *DRepUpdate {
+	return &DRepUpdate{Credential: *cred, Anchor: anchor}
+}
+
+// Type returns DRepUpdateCertificateType.
+func (d *DRepUpdate) Type() CertificateType {
+	return DRepUpdateCertificateType
+}
+
+// MarshalCBOR returns the cbor encoding of the drep update certificate.
+func (d *DRepUpdate) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{d.Type(), d.Credential, optionalAnchor(d.Anchor)})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded drep update certificate.
+func (d *DRepUpdate) UnmarshalCBOR(data []byte) error {
+	cert := DRepUpdate{}
+	if err := unmarshalTypedArray(data, uint(DRepUpdateCertificateType), ErrInvalidCertificate, &cert.Credential, &cert.Anchor); err != nil {
+		return err
+	}
+	*d = cert
+	return nil
+}
@@ -1,12 +1,20 @@
 package tx
 
 import (
+	"bytes"
 	"encoding/hex"
+	"errors"
 
 	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
 	"github.com/fxamacker/cbor/v2"
 )
 
+var (
+	ErrInvalidTxInput  = errors.New("invalid transaction input")
+	ErrInvalidTxOutput = errors.New("invalid transaction output")
+)
+
 type TxInput struct {
 	cbor.Marshaler
 
@@ -39,10 +47,32 @@ func (txI *TxInput) MarshalCBOR() ([]byte, error) {
 	return cbor.Marshal(input)
 }
 
+// UnmarshalCBOR deserializes a cbor encoded `[tx hash, index]` input. The amount is not part of the encoding
+// and left zero.
+func (txI *TxInput) UnmarshalCBOR(data []byte) error {
+	var txHash crypto.TransactionHash
+	input := TxInput{}
+	if err := unmarshalArray(data, ErrInvalidTxInput, fixed(txHash[:]), &input.Index); err != nil {
+		return err
+	}
+	input.TxHash = txHash[:]
+	*txI = input
+	return nil
+}
+
 type TxOutput struct {
 	_       struct{} `cbor:",toarray"`
 	Address address.Address
 	Amount  uint
+
+	decoded *decodedOutput
+}
+
+// decodedOutput is the original encoding of a decoded output with the address and amount it was decoded to.
+type decodedOutput struct {
+	raw     []byte
+	address []byte
+	amount  uint
 }
 
 func NewTxOutput(addr address.Address, amount uint) *TxOutput {
@@ -51,3 +81,72 @@ func NewTxOutput(addr address.Address, amount uint) *TxOutput {
 		Amount:  amount,
 	}
 }
+
+// MarshalCBOR returns the cbor encoding of the output. Decoded outputs keep their original encoding, including
+// native tokens, datums and reference scripts, as long as their address and amount are unchanged.
+func (o *TxOutput) MarshalCBOR() ([]byte, error) {
+	if d := o.decoded; d != nil && o.Amount == d.amount && o.Address != nil && bytes.Equal(o.Address.Bytes(), d.address) {
+		return d.raw, nil
+	}
+	type arrayOutput TxOutput
+	return cbor.Marshal((*arrayOutput)(o))
+}
+
+// UnmarshalCBOR deserializes a cbor encoded output in the legacy array or the post-alonzo map format.
+// The amount is the lovelace value of the output.
+func (o *TxOutput) UnmarshalCBOR(data []byte) error {
+	var (
+		addr  []byte
+		value cbor.RawMessage
+	)
+	if len(data) > 0 && data[0]>>5 == 5 {
+		var raw struct {
+			Address []byte          `cbor:"0,keyasint"`
+			Value   cbor.RawMessage `cbor:"1,keyasint"`
+		}
+		if err := cbor.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		addr, value = raw.Address, raw.Value
+	} else {
+		var raw []cbor.RawMessage
+		if err := cbor.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		// [address, value] with an optional datum hash since alonzo
+		if len(raw) != 2 && len(raw) != 3 {
+			return ErrInvalidTxOutput
+		}
+		if err := cbor.Unmarshal(raw[0], &addr); err != nil {
+			return err
+		}
+		value = raw[1]
+	}
+
+	// The value is the lovelace amount or `[amount, multiasset]`.
+	if len(value) > 0 && value[0]>>5 == 4 {
+		var multiasset []cbor.RawMessage
+		if err := cbor.Unmarshal(value, &multiasset); err != nil {
+			return err
+		}
+		if len(multiasset) != 2 {
+			return ErrInvalidTxOutput
+		}
+		value = multiasset[0]
+	}
+	var amount uint
+	if err := cbor.Unmarshal(value, &amount); err != nil {
+		return err
+	}
+
+	a, err := address.NewAddressFromBytes(addr)
+	if err != nil {
+		return err
+	}
+	*o = TxOutput{
+		Address: a,
+		Amount:  amount,
+		decoded: &decodedOutput{raw: append([]byte{}, data...), address: addr, amount: amount},
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package tx
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// MessageLabel is the metadata label of CIP-20 transaction messages.
+	MessageLabel = 674
+
+	// DefaultMessagePassphrase is the CIP-83 passphrase used when none is provided.
+	DefaultMessagePassphrase = "cardano"
+
+	// basicEncryption is the CIP-83 `basic` mode, aes-256-cbc with a pbkdf2 derived key compatible with openssl.
+	basicEncryption        = "basic"
+	opensslSaltHeader      = "Salted__"
+	opensslSaltLen         = 8
+	basicEncryptionKeyIter = 10000
+)
+
+var (
+	ErrMissingMessage          = errors.New("transaction metadata has no message")
+	ErrInvalidMessage          = errors.New("invalid transaction message")
+	ErrEncryptedMessage        = errors.New("transaction message is encrypted")
+	ErrUnsupportedEncryption   = errors.New("unsupported message encryption mode")
+	ErrMessageDecryptionFailed = errors.New("message decryption failed, wrong passphrase or corrupted message")
+)
+
+// messageLines returns the text metadatum of the lines, splitting lines longer than 64 bytes into several lines.
+func messageLines(lines []string) *TransactionMetadatum {
+	msg := NewListMetadatum()
+	for _, line := range lines {
+		chunks := chunkText(line, MetadatumMaxLen)
+		if len(chunks) == 0 {
+			chunks = []string{""}
+		}
+		for _, chunk := range chunks {
+			msg.List = append(msg.List, &TransactionMetadatum{Kind: TextMetadatum, Text: chunk})
+		}
+	}
+	return msg
+}
+
+// NewMessageMetadata returns the CIP-20 metadata of a transaction message. Lines longer than 64 bytes
+// are split into several lines.
+func NewMessageMetadata(lines ...string) TransactionMetadata {
+	return TransactionMetadata{
+		MessageLabel: NewMapMetadatum(MetadatumEntry{Key: NewTextMetadatum("msg"), Value: messageLines(lines)}),
+	}
+}
+
+// NewEncryptedMessageMetadata returns the CIP-83 metadata of a transaction message encrypted with the passphrase,
+// or the default passphrase if it is empty.
+func NewEncryptedMessageMetadata(passphrase string, lines ...string) (TransactionMetadata, error) {
+	msg, err := messageLines(lines).noSchemaValue()
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := json.Marshal(map[string]interface{}{"msg": msg})
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := encryptBasic(passphrase, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	encoded := base64.StdEncoding.EncodeToString(ciphertext)
+
+	return TransactionMetadata{
+		MessageLabel: NewMapMetadatum(
+			MetadatumEntry{Key: NewTextMetadatum("enc"), Value: NewTextMetadatum(basicEncryption)},
+			MetadatumEntry{Key: NewTextMetadatum("msg"), Value: messageLines(chunkText(encoded, MetadatumMaxLen))},
+		),
+	}, nil
+}
+
+// messageFields returns the encryption mode and message lines of the label 674 metadata.
+func (m TransactionMetadata) messageFields() (enc string, lines []string, err error) {
+	message, ok := m[MessageLabel]
+	if !ok {
+		return "", nil, ErrMissingMessage
+	}
+	if message.Kind != MapMetadatum {
+		return "", nil, ErrInvalidMessage
+	}
+
+	for _, entry := range message.Map {
+		if entry.Key.Kind != TextMetadatum {
+			continue
+		}
+		switch entry.Key.Text {
+		case "enc":
+			if entry.Value.Kind != TextMetadatum {
+				return "", nil, ErrInvalidMessage
+			}
+			enc = entry.Value.Text
+		case "msg":
+			if entry.Value.Kind != ListMetadatum {
+				return "", nil, ErrInvalidMessage
+			}
+			for _, line := range entry.Value.List {
+				if line.Kind != TextMetadatum {
+					return "", nil, ErrInvalidMessage
+				}
+				lines = append(lines, line.Text)
+			}
+		}
+	}
+	if lines == nil {
+		return "", nil, ErrMissingMessage
+	}
+
+	return enc, lines, nil
+}
+
+// Message returns the lines of the CIP-20 message of the metadata. Encrypted messages return ErrEncryptedMessage.
+func (m TransactionMetadata) Message() ([]string, error) {
+	enc, lines, err := m.messageFields()
+	if err != nil {
+		return nil, err
+	}
+	if enc != "" {
+		return nil, ErrEncryptedMessage
+	}
+	return lines, nil
+}
+
+// DecryptMessage returns the lines of the CIP-83 encrypted message of the metadata decrypted with the passphrase,
+// or the default passphrase if it is empty. Unencrypted messages are returned as they are.
+func (m TransactionMetadata) DecryptMessage(passphrase string) ([]string, error) {
+	enc, lines, err := m.messageFields()
+	if err != nil {
+		return nil, err
+	}
+	switch enc {
+	case "":
+		return lines, nil
+	case basicEncryption:
+	default:
+		return nil, ErrUnsupportedEncryption
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(strings.Join(lines, ""))
+	if err != nil {
+		return nil, ErrInvalidMessage
+	}
+	plaintext, err := decryptBasic(passphrase, ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	var decrypted struct {
+		Msg []string `json:"msg"`
+	}
+	if err := json.Unmarshal(plaintext, &decrypted); err != nil {
+		return nil, ErrInvalidMessage
+	}
+	return decrypted.Msg, nil
+}
+
+// basicKey derives the aes-256 key and iv from the passphrase and salt like `openssl enc -pbkdf2 -iter 10000 -md sha256`.
+func basicKey(passphrase string, salt []byte) (key, iv []byte) {
+	if passphrase == "" {
+		passphrase = DefaultMessagePassphrase
+	}
+	derived := pbkdf2.Key([]byte(passphrase), salt, basicEncryptionKeyIter, 32+aes.BlockSize, sha256.New)
+	return derived[:32], derived[32:]
+}
+
+// encryptBasic encrypts the plaintext in the openssl salted format.
+func encryptBasic(passphrase string, plaintext []byte) ([]byte, error) {
+	salt := make([]byte, opensslSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	key, iv := basicKey(passphrase, salt)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	padding := aes.BlockSize - len(plaintext)%aes.BlockSize
+	padded := append(append([]byte{}, plaintext...), bytes.Repeat([]byte{byte(padding)}, padding)...)
+
+	out := append([]byte(opensslSaltHeader), salt...)
+	ciphertext := make([]byte, len(padded))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, padded)
+	return append(out, ciphertext...), nil
+}
+
+// decryptBasic decrypts a ciphertext in the openssl salted format.
+func decryptBasic(passphrase string, data []byte) ([]byte, error) {
+	headerLen := len(opensslSaltHeader) + opensslSaltLen
+	if len(data) < headerLen+aes.BlockSize || string(data[:len(opensslSaltHeader)]) != opensslSaltHeader {
+		return nil, ErrInvalidMessage
+	}
+	ciphertext := data[headerLen:]
+	if len(ciphertext)%aes.BlockSize != 0 {
+		return nil, ErrInvalidMessage
+	}
+
+	key, iv := basicKey(passphrase, data[len(opensslSaltHeader):headerLen])
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+
+	padding := int(plaintext[len(plaintext)-1])
+	if padding == 0 || padding > aes.BlockSize || !bytes.Equal(plaintext[len(plaintext)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return nil, ErrMessageDecryptionFailed
+	}
+	return plaintext[:len(plaintext)-padding], nil
+}
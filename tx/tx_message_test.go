@@ -0,0 +1,69 @@
+package tx_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/fivebinaries/go-cardano-serialization/network"
+	"github.com/fivebinaries/go-cardano-serialization/protocol"
+	"github.com/fivebinaries/go-cardano-serialization/tx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageMetadata(t *testing.T) {
+	long := strings.Repeat("a", 70)
+	metadata := tx.NewMessageMetadata("Invoice-No: 1234567890", long)
+	data, err := metadata.JSON(tx.NoSchema)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"674":{"msg":["Invoice-No: 1234567890","`+strings.Repeat("a", 64)+`","aaaaaa"]}}`, string(data))
+
+	lines, err := metadata.Message()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Invoice-No: 1234567890", strings.Repeat("a", 64), "aaaaaa"}, lines)
+
+	_, err = tx.TransactionMetadata{}.Message()
+	assert.ErrorIs(t, err, tx.ErrMissingMessage)
+
+	// Invalid utf-8 is split at the chunk size.
+	lines, err = tx.NewMessageMetadata(strings.Repeat("\x80", 200)).Message()
+	assert.NoError(t, err)
+	assert.Len(t, lines, 4)
+}
+
+func TestEncryptedMessageMetadata(t *testing.T) {
+	// Encrypted with `openssl enc -e -aes-256-cbc -pbkdf2 -iter 10000 -md sha256 -a -A -k cardano`
+	metadata, err := tx.NewTransactionMetadataFromJSON([]byte(`{"674":{"enc":"basic","msg":[`+
+		`"U2FsdGVkX18ILYZ8N0Zk2oXHlM1eb0iSMlBOBL2AdYjHSCWGwISwNmekq+sUjEq5",`+
+		`"1xctTvmz1lZ8+yWKn2B4Hiy0xOKoNEBlTIBJSE+l3fU="]}}`), tx.NoSchema)
+	assert.NoError(t, err)
+
+	_, err = metadata.Message()
+	assert.ErrorIs(t, err, tx.ErrEncryptedMessage)
+	lines, err := metadata.DecryptMessage("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Invoice-No: 1234567890", "Customer-No: 555-1234"}, lines)
+	_, err = metadata.DecryptMessage("wrong")
+	assert.Error(t, err)
+
+	addr, utxoPrv, err := generateBaseAddress(network.TestNet())
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder := tx.NewTxBuilder(protocol.Protocol{TxFeePerByte: 44, TxFeeFixed: 155381}, []bip32.XPrv{utxoPrv})
+	builder.AddInputs(tx.NewTxInput("fcbc18c64cdf133f33dd319c5105dc7c4972f2d646ae276fbd00cf7f39f8c380", 0, 1000000000))
+	assert.NoError(t, builder.SetMetadata(tx.TransactionMetadata{1: tx.NewIntMetadatum(1)}))
+	assert.NoError(t, builder.SetEncryptedMessage("secret", "Order-No: 7654321", strings.Repeat("b", 100)))
+	builder.AddChangeIfNeeded(addr)
+	txFinal, err := builder.Build()
+	assert.NoError(t, err)
+
+	data, err := txFinal.Bytes()
+	assert.NoError(t, err)
+	aux, err := tx.NewAuxiliaryDataFromTxBytes(data)
+	assert.NoError(t, err)
+	assert.Equal(t, tx.NewIntMetadatum(1), aux.Metadata[1])
+	lines, err = aux.Metadata.DecryptMessage("secret")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Order-No: 7654321", strings.Repeat("b", 64), strings.Repeat("b", 36)}, lines)
+}
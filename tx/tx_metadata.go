@@ -0,0 +1,330 @@
+package tx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"unicode/utf8"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// MetadatumMaxLen is the maximum length in bytes of bytes and text metadata values accepted by the ledger.
+const MetadatumMaxLen = 64
+
+var (
+	ErrInvalidMetadatum  = errors.New("invalid transaction metadatum")
+	ErrMetadatumTooLong  = errors.New("metadatum bytes or text longer than 64 bytes")
+	ErrMetadatumIntRange = errors.New("metadatum integer out of range")
+)
+
+var (
+	maxMetadatumInt = new(big.Int).SetUint64(math.MaxUint64)
+	minMetadatumInt = new(big.Int).Neg(new(big.Int).Add(maxMetadatumInt, big.NewInt(1)))
+)
+
+// MetadatumKind is the kind of value held by a TransactionMetadatum.
+type MetadatumKind uint8
+
+const (
+	IntMetadatum MetadatumKind = iota
+	BytesMetadatum
+	TextMetadatum
+	ListMetadatum
+	MapMetadatum
+)
+
+// TransactionMetadatum is a transaction metadata value. Only the field matching Kind is used.
+type TransactionMetadatum struct {
+	Kind  MetadatumKind
+	Int   *big.Int
+	Bytes []byte
+	Text  string
+	List  []*TransactionMetadatum
+	Map   []MetadatumEntry
+}
+
+// MetadatumEntry is a key value pair of a map metadatum. Entries keep their insertion order.
+type MetadatumEntry struct {
+	Key   *TransactionMetadatum
+	Value *TransactionMetadatum
+}
+
+// NewIntMetadatum returns a pointer to a new integer metadatum.
+func NewIntMetadatum(i int64) *TransactionMetadatum {
+	return &TransactionMetadatum{Kind: IntMetadatum, Int: big.NewInt(i)}
+}
+
+// NewBigIntMetadatum returns a pointer to a new integer metadatum. The ledger accepts integers in [-2^64, 2^64-1].
+func NewBigIntMetadatum(i *big.Int) (*TransactionMetadatum, error) {
+	if i.Cmp(minMetadatumInt) < 0 || i.Cmp(maxMetadatumInt) > 0 {
+		return nil, ErrMetadatumIntRange
+	}
+	return &TransactionMetadatum{Kind: IntMetadatum, Int: new(big.Int).Set(i)}, nil
+}
+
+// NewBytesMetadatum returns a pointer to a new bytes metadatum. Values longer than 64 bytes
+// are split into a list of 64 byte chunks.
+func NewBytesMetadatum(b []byte) *TransactionMetadatum {
+	if len(b) <= MetadatumMaxLen {
+		return &TransactionMetadatum{Kind: BytesMetadatum, Bytes: b}
+	}
+
+	list := &TransactionMetadatum{Kind: ListMetadatum}
+	for len(b) > 0 {
+		n := len(b)
+		if n > MetadatumMaxLen {
+			n = MetadatumMaxLen
+		}
+		list.List = append(list.List, &TransactionMetadatum{Kind: BytesMetadatum, Bytes: b[:n]})
+		b = b[n:]
+	}
+	return list
+}
+
+// NewTextMetadatum returns a pointer to a new text metadatum. Texts longer than 64 bytes
+// are split into a list of chunks of at most 64 bytes without splitting utf-8 characters.
+func NewTextMetadatum(s string) *TransactionMetadatum {
+	if len(s) <= MetadatumMaxLen {
+		return &TransactionMetadatum{Kind: TextMetadatum, Text: s}
+	}
+
+	list := &TransactionMetadatum{Kind: ListMetadatum}
+	for _, chunk := range chunkText(s, MetadatumMaxLen) {
+		list.List = append(list.List, &TransactionMetadatum{Kind: TextMetadatum, Text: chunk})
+	}
+	return list
+}
+
+// chunkText splits s into chunks of at most size bytes at utf-8 character boundaries.
+// Invalid utf-8 without a character boundary is split at size bytes.
+func chunkText(s string, size int) (chunks []string) {
+	for len(s) > size {
+		n := size
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		if n == 0 {
+			n = size
+		}
+		chunks = append(chunks, s[:n])
+		s = s[n:]
+	}
+	if len(s) > 0 {
+		chunks = append(chunks, s)
+	}
+	return
+}
+
+// NewListMetadatum returns a pointer to a new list metadatum of the items.
+func NewListMetadatum(items ...*TransactionMetadatum) *TransactionMetadatum {
+	return &TransactionMetadatum{Kind: ListMetadatum, List: items}
+}
+
+// NewMapMetadatum returns a pointer to a new map metadatum of the entries.
+func NewMapMetadatum(entries ...MetadatumEntry) *TransactionMetadatum {
+	return &TransactionMetadatum{Kind: MapMetadatum, Map: entries}
+}
+
+// Validate checks the metadatum and its nested values against the ledger rules.
+func (m *TransactionMetadatum) Validate() error {
+	if m == nil {
+		return ErrInvalidMetadatum
+	}
+
+	switch m.Kind {
+	case IntMetadatum:
+		if m.Int == nil {
+			return ErrInvalidMetadatum
+		}
+		if m.Int.Cmp(minMetadatumInt) < 0 || m.Int.Cmp(maxMetadatumInt) > 0 {
+			return ErrMetadatumIntRange
+		}
+	case BytesMetadatum:
+		if len(m.Bytes) > MetadatumMaxLen {
+			return ErrMetadatumTooLong
+		}
+	case TextMetadatum:
+		if len(m.Text) > MetadatumMaxLen {
+			return ErrMetadatumTooLong
+		}
+		if !utf8.ValidString(m.Text) {
+			return fmt.Errorf("%w: text is not valid utf-8", ErrInvalidMetadatum)
+		}
+	case ListMetadatum:
+		for _, item := range m.List {
+			if err := item.Validate(); err != nil {
+				return err
+			}
+		}
+	case MapMetadatum:
+		for _, entry := range m.Map {
+			if err := entry.Key.Validate(); err != nil {
+				return err
+			}
+			if err := entry.Value.Validate(); err != nil {
+				return err
+			}
+		}
+	default:
+		return ErrInvalidMetadatum
+	}
+
+	return nil
+}
+
+// MarshalCBOR returns the cbor encoding of the metadatum after validating it.
+func (m *TransactionMetadatum) MarshalCBOR() ([]byte, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m.marshal()
+}
+
+func (m *TransactionMetadatum) marshal() ([]byte, error) {
+	switch m.Kind {
+	case IntMetadatum:
+		return cbor.Marshal(m.Int)
+	case BytesMetadatum:
+		return cbor.Marshal(m.Bytes)
+	case TextMetadatum:
+		return cbor.Marshal(m.Text)
+	case ListMetadatum:
+		out := cborHeader(4, uint64(len(m.List)))
+		for _, item := range m.List {
+			data, err := item.marshal()
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, data...)
+		}
+		return out, nil
+	case MapMetadatum:
+		out := cborHeader(5, uint64(len(m.Map)))
+		for _, entry := range m.Map {
+			key, err := entry.Key.marshal()
+			if err != nil {
+				return nil, err
+			}
+			value, err := entry.Value.marshal()
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, key...)
+			out = append(out, value...)
+		}
+		return out, nil
+	}
+	return nil, ErrInvalidMetadatum
+}
+
+// UnmarshalCBOR deserializes a cbor encoded metadatum. Map entries keep their encoded order.
+func (m *TransactionMetadatum) UnmarshalCBOR(data []byte) error {
+	if len(data) == 0 {
+		return ErrInvalidMetadatum
+	}
+
+	var decoded TransactionMetadatum
+	switch data[0] >> 5 {
+	case 0, 1:
+		decoded.Kind = IntMetadatum
+		decoded.Int = new(big.Int)
+		if err := cbor.Unmarshal(data, decoded.Int); err != nil {
+			return err
+		}
+	case 2:
+		decoded.Kind = BytesMetadatum
+		if err := cbor.Unmarshal(data, &decoded.Bytes); err != nil {
+			return err
+		}
+	case 3:
+		decoded.Kind = TextMetadatum
+		if err := cbor.Unmarshal(data, &decoded.Text); err != nil {
+			return err
+		}
+	case 4:
+		decoded.Kind = ListMetadatum
+		if err := cbor.Unmarshal(data, &decoded.List); err != nil {
+			return err
+		}
+		for _, item := range decoded.List {
+			if item == nil {
+				return ErrInvalidMetadatum
+			}
+		}
+	case 5:
+		decoded.Kind = MapMetadatum
+		keys, values, err := unmarshalMap(data)
+		if err != nil {
+			return err
+		}
+		for i := range keys {
+			entry := MetadatumEntry{Key: &TransactionMetadatum{}, Value: &TransactionMetadatum{}}
+			if err := entry.Key.UnmarshalCBOR(keys[i]); err != nil {
+				return err
+			}
+			if err := entry.Value.UnmarshalCBOR(values[i]); err != nil {
+				return err
+			}
+			decoded.Map = append(decoded.Map, entry)
+		}
+	default:
+		return fmt.Errorf("%w: unexpected cbor major type %d", ErrInvalidMetadatum, data[0]>>5)
+	}
+
+	if err := decoded.validateValue(); err != nil {
+		return err
+	}
+	*m = decoded
+	return nil
+}
+
+// validateValue checks the metadatum without its nested values, which are checked when they are decoded.
+func (m *TransactionMetadatum) validateValue() error {
+	if m.Kind == ListMetadatum || m.Kind == MapMetadatum {
+		return nil
+	}
+	return m.Validate()
+}
+
+// TransactionMetadata maps metadata labels to their metadatum.
+type TransactionMetadata map[uint64]*TransactionMetadatum
+
+// Validate checks every metadatum against the ledger rules.
+func (m TransactionMetadata) Validate() error {
+	for label, metadatum := range m {
+		if err := metadatum.Validate(); err != nil {
+			return fmt.Errorf("label %d: %w", label, err)
+		}
+	}
+	return nil
+}
+
+// MarshalCBOR returns the cbor encoding of the metadata with labels in canonical order.
+func (m TransactionMetadata) MarshalCBOR() ([]byte, error) {
+	var labels, values [][]byte
+	for label, metadatum := range m {
+		key, err := cbor.Marshal(label)
+		if err != nil {
+			return nil, err
+		}
+		value, err := metadatum.MarshalCBOR()
+		if err != nil {
+			return nil, fmt.Errorf("label %d: %w", label, err)
+		}
+		labels = append(labels, key)
+		values = append(values, value)
+	}
+	return marshalMap(labels, values), nil
+}
+
+// UnmarshalCBOR deserializes cbor encoded metadata.
+func (m *TransactionMetadata) UnmarshalCBOR(data []byte) error {
+	var metadata map[uint64]*TransactionMetadatum
+	if err := cbor.Unmarshal(data, &metadata); err != nil {
+		return err
+	}
+	*m = metadata
+	return nil
+}
@@ -0,0 +1,361 @@
+package tx
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MetadataJSONSchema is one of the json representations of transaction metadata used by cardano-cli.
+type MetadataJSONSchema uint8
+
+const (
+	// NoSchema maps json values directly to metadata. Strings starting with `0x` are bytes,
+	// object keys are converted like strings or to integers when they are decimal numbers.
+	NoSchema MetadataJSONSchema = iota
+	// DetailedSchema tags every value with its type, e.g. `{"int": 1}` or `{"map": [{"k": ..., "v": ...}]}`.
+	DetailedSchema
+)
+
+// jsonObject is a json object which keeps the order of its fields when marshalled.
+type jsonObject []jsonField
+
+type jsonField struct {
+	key   string
+	value interface{}
+}
+
+// MarshalJSON returns the json encoding of the object with fields in order.
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// decodeJSON unmarshals json keeping numbers as json.Number.
+func decodeJSON(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// sortedKeys returns the keys of a json object in lexicographic order, as cardano-cli orders them.
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// NewTransactionMetadataFromJSON returns the metadata of a cardano-cli json metadata file.
+// The top level object maps decimal labels to values in the schema.
+func NewTransactionMetadataFromJSON(data []byte, schema MetadataJSONSchema) (TransactionMetadata, error) {
+	v, err := decodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: metadata must be a json object", ErrInvalidMetadatum)
+	}
+
+	metadata := TransactionMetadata{}
+	for key, value := range obj {
+		label, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: label %q is not an unsigned integer", ErrInvalidMetadatum, key)
+		}
+		metadatum, err := metadatumFromJSON(value, schema)
+		if err != nil {
+			return nil, fmt.Errorf("label %d: %w", label, err)
+		}
+		metadata[label] = metadatum
+	}
+	return metadata, nil
+}
+
+// JSON returns the cardano-cli json representation of the metadata in the schema.
+func (m TransactionMetadata) JSON(schema MetadataJSONSchema) ([]byte, error) {
+	labels := make([]uint64, 0, len(m))
+	for label := range m {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i] < labels[j] })
+
+	obj := jsonObject{}
+	for _, label := range labels {
+		value, err := m[label].jsonValue(schema)
+		if err != nil {
+			return nil, fmt.Errorf("label %d: %w", label, err)
+		}
+		obj = append(obj, jsonField{strconv.FormatUint(label, 10), value})
+	}
+	return json.Marshal(obj)
+}
+
+// NewMetadatumFromJSON returns a pointer to the metadatum of a json value in the schema.
+func NewMetadatumFromJSON(data []byte, schema MetadataJSONSchema) (*TransactionMetadatum, error) {
+	v, err := decodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return metadatumFromJSON(v, schema)
+}
+
+// JSON returns the json representation of the metadatum in the schema.
+func (m *TransactionMetadatum) JSON(schema MetadataJSONSchema) ([]byte, error) {
+	value, err := m.jsonValue(schema)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+func metadatumFromJSON(v interface{}, schema MetadataJSONSchema) (*TransactionMetadatum, error) {
+	var metadatum *TransactionMetadatum
+	var err error
+	switch schema {
+	case NoSchema:
+		metadatum, err = metadatumFromNoSchema(v)
+	case DetailedSchema:
+		metadatum, err = metadatumFromDetailedSchema(v)
+	default:
+		return nil, fmt.Errorf("%w: unknown json schema %d", ErrInvalidMetadatum, schema)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return metadatum, metadatum.Validate()
+}
+
+func intFromJSON(n json.Number) (*TransactionMetadatum, error) {
+	i, ok := new(big.Int).SetString(n.String(), 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: number %s is not an integer", ErrInvalidMetadatum, n)
+	}
+	return NewBigIntMetadatum(i)
+}
+
+// stringFromNoSchema returns bytes for `0x` prefixed hex strings and text otherwise.
+func stringFromNoSchema(s string) *TransactionMetadatum {
+	if strings.HasPrefix(s, "0x") {
+		if b, err := hex.DecodeString(s[2:]); err == nil {
+			return &TransactionMetadatum{Kind: BytesMetadatum, Bytes: b}
+		}
+	}
+	return &TransactionMetadatum{Kind: TextMetadatum, Text: s}
+}
+
+func metadatumFromNoSchema(v interface{}) (*TransactionMetadatum, error) {
+	switch value := v.(type) {
+	case json.Number:
+		return intFromJSON(value)
+	case string:
+		return stringFromNoSchema(value), nil
+	case []interface{}:
+		list := NewListMetadatum()
+		for _, item := range value {
+			metadatum, err := metadatumFromNoSchema(item)
+			if err != nil {
+				return nil, err
+			}
+			list.List = append(list.List, metadatum)
+		}
+		return list, nil
+	case map[string]interface{}:
+		m := NewMapMetadatum()
+		for _, key := range sortedKeys(value) {
+			k := stringFromNoSchema(key)
+			if i, ok := new(big.Int).SetString(key, 10); ok {
+				k = &TransactionMetadatum{Kind: IntMetadatum, Int: i}
+			}
+			metadatum, err := metadatumFromNoSchema(value[key])
+			if err != nil {
+				return nil, err
+			}
+			m.Map = append(m.Map, MetadatumEntry{Key: k, Value: metadatum})
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("%w: json value %v has no metadata representation", ErrInvalidMetadatum, v)
+}
+
+func metadatumFromDetailedSchema(v interface{}) (*TransactionMetadatum, error) {
+	obj, ok := v.(map[string]interface{})
+	if !ok || len(obj) != 1 {
+		return nil, fmt.Errorf("%w: expected an object with a single type field", ErrInvalidMetadatum)
+	}
+
+	for kind, value := range obj {
+		switch kind {
+		case "int":
+			if n, ok := value.(json.Number); ok {
+				return intFromJSON(n)
+			}
+		case "bytes":
+			if s, ok := value.(string); ok {
+				b, err := hex.DecodeString(s)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %s", ErrInvalidMetadatum, err)
+				}
+				return &TransactionMetadatum{Kind: BytesMetadatum, Bytes: b}, nil
+			}
+		case "string":
+			if s, ok := value.(string); ok {
+				return &TransactionMetadatum{Kind: TextMetadatum, Text: s}, nil
+			}
+		case "list":
+			if items, ok := value.([]interface{}); ok {
+				list := NewListMetadatum()
+				for _, item := range items {
+					metadatum, err := metadatumFromDetailedSchema(item)
+					if err != nil {
+						return nil, err
+					}
+					list.List = append(list.List, metadatum)
+				}
+				return list, nil
+			}
+		case "map":
+			if entries, ok := value.([]interface{}); ok {
+				m := NewMapMetadatum()
+				for _, e := range entries {
+					entry, ok := e.(map[string]interface{})
+					if !ok || len(entry) != 2 || entry["k"] == nil || entry["v"] == nil {
+						return nil, fmt.Errorf("%w: map entries must be objects with fields k and v", ErrInvalidMetadatum)
+					}
+					key, err := metadatumFromDetailedSchema(entry["k"])
+					if err != nil {
+						return nil, err
+					}
+					val, err := metadatumFromDetailedSchema(entry["v"])
+					if err != nil {
+						return nil, err
+					}
+					m.Map = append(m.Map, MetadatumEntry{Key: key, Value: val})
+				}
+				return m, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: invalid %q field", ErrInvalidMetadatum, kind)
+	}
+	return nil, ErrInvalidMetadatum
+}
+
+func (m *TransactionMetadatum) jsonValue(schema MetadataJSONSchema) (interface{}, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	switch schema {
+	case NoSchema:
+		return m.noSchemaValue()
+	case DetailedSchema:
+		return m.detailedSchemaValue(), nil
+	}
+	return nil, fmt.Errorf("%w: unknown json schema %d", ErrInvalidMetadatum, schema)
+}
+
+func (m *TransactionMetadatum) noSchemaValue() (interface{}, error) {
+	switch m.Kind {
+	case IntMetadatum:
+		return json.Number(m.Int.String()), nil
+	case BytesMetadatum:
+		return "0x" + hex.EncodeToString(m.Bytes), nil
+	case TextMetadatum:
+		return m.Text, nil
+	case ListMetadatum:
+		list := make([]interface{}, 0, len(m.List))
+		for _, item := range m.List {
+			value, err := item.noSchemaValue()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		return list, nil
+	case MapMetadatum:
+		obj := jsonObject{}
+		for _, entry := range m.Map {
+			key, err := entry.Key.noSchemaKey()
+			if err != nil {
+				return nil, err
+			}
+			value, err := entry.Value.noSchemaValue()
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, jsonField{key, value})
+		}
+		return obj, nil
+	}
+	return nil, ErrInvalidMetadatum
+}
+
+// noSchemaKey returns the json object key of a map key. Lists and maps are keyed by their json encoding.
+func (m *TransactionMetadatum) noSchemaKey() (string, error) {
+	switch m.Kind {
+	case IntMetadatum:
+		return m.Int.String(), nil
+	case BytesMetadatum:
+		return "0x" + hex.EncodeToString(m.Bytes), nil
+	case TextMetadatum:
+		return m.Text, nil
+	}
+	value, err := m.noSchemaValue()
+	if err != nil {
+		return "", err
+	}
+	key, err := json.Marshal(value)
+	return string(key), err
+}
+
+func (m *TransactionMetadatum) detailedSchemaValue() interface{} {
+	switch m.Kind {
+	case IntMetadatum:
+		return jsonObject{{"int", json.Number(m.Int.String())}}
+	case BytesMetadatum:
+		return jsonObject{{"bytes", hex.EncodeToString(m.Bytes)}}
+	case TextMetadatum:
+		return jsonObject{{"string", m.Text}}
+	case ListMetadatum:
+		list := make([]interface{}, 0, len(m.List))
+		for _, item := range m.List {
+			list = append(list, item.detailedSchemaValue())
+		}
+		return jsonObject{{"list", list}}
+	}
+
+	entries := make([]interface{}, 0, len(m.Map))
+	for _, entry := range m.Map {
+		entries = append(entries, jsonObject{{"k", entry.Key.detailedSchemaValue()}, {"v", entry.Value.detailedSchemaValue()}})
+	}
+	return jsonObject{{"map", entries}}
+}
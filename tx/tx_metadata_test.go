@@ -0,0 +1,108 @@
+package tx_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/protocol"
+	"github.com/fivebinaries/go-cardano-serialization/tx"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadatumEncoding(t *testing.T) {
+	maxInt, _ := new(big.Int).SetString("18446744073709551615", 10)
+	bigInt, err := tx.NewBigIntMetadatum(maxInt)
+	assert.NoError(t, err)
+	_, err = tx.NewBigIntMetadatum(new(big.Int).Add(maxInt, big.NewInt(1)))
+	assert.ErrorIs(t, err, tx.ErrMetadatumIntRange)
+
+	metadata := tx.TransactionMetadata{
+		674: tx.NewMapMetadatum(tx.MetadatumEntry{
+			Key:   tx.NewTextMetadatum("msg"),
+			Value: tx.NewListMetadatum(tx.NewTextMetadatum("hi"), tx.NewIntMetadatum(-1), tx.NewBytesMetadatum([]byte{0xca, 0xfe})),
+		}),
+		1: bigInt,
+	}
+	data, err := metadata.MarshalCBOR()
+	assert.NoError(t, err)
+	assert.Equal(t, "a2011bffffffffffffffff1902a2a1636d7367836268692042cafe", hex.EncodeToString(data))
+
+	var decoded tx.TransactionMetadata
+	assert.NoError(t, cbor.Unmarshal(data, &decoded))
+	assert.Equal(t, metadata, decoded)
+
+	// Indefinite length maps keep their entry order
+	var m tx.TransactionMetadatum
+	assert.NoError(t, cbor.Unmarshal([]byte{0xbf, 0x02, 0x61, 0x62, 0x01, 0x61, 0x61, 0xff}, &m))
+	assert.Equal(t, tx.NewMapMetadatum(
+		tx.MetadatumEntry{Key: tx.NewIntMetadatum(2), Value: tx.NewTextMetadatum("b")},
+		tx.MetadatumEntry{Key: tx.NewIntMetadatum(1), Value: tx.NewTextMetadatum("a")},
+	), &m)
+
+	assert.ErrorIs(t, cbor.Unmarshal([]byte{0xf9, 0x3c, 0x00}, &m), tx.ErrInvalidMetadatum)
+	tooLong := append([]byte{0x78, 65}, []byte(strings.Repeat("a", 65))...)
+	assert.ErrorIs(t, cbor.Unmarshal(tooLong, &m), tx.ErrMetadatumTooLong)
+}
+
+func TestMetadatumChunking(t *testing.T) {
+	text := strings.Repeat("a", 63) + "é" + strings.Repeat("b", 10)
+	chunked := tx.NewTextMetadatum(text)
+	assert.Equal(t, tx.ListMetadatum, chunked.Kind)
+	assert.Equal(t, strings.Repeat("a", 63), chunked.List[0].Text)
+	assert.Equal(t, "é"+strings.Repeat("b", 10), chunked.List[1].Text)
+	assert.NoError(t, chunked.Validate())
+
+	chunked = tx.NewTextMetadatum(strings.Repeat("\x80", 200))
+	assert.Len(t, chunked.List, 4)
+	assert.Equal(t, strings.Repeat("\x80", 64), chunked.List[0].Text)
+	assert.Equal(t, strings.Repeat("\x80", 8), chunked.List[3].Text)
+
+	chunked = tx.NewBytesMetadatum(make([]byte, 130))
+	assert.Len(t, chunked.List, 3)
+	assert.Len(t, chunked.List[2].Bytes, 2)
+
+	invalid := &tx.TransactionMetadatum{Kind: tx.TextMetadatum, Text: text}
+	assert.ErrorIs(t, invalid.Validate(), tx.ErrMetadatumTooLong)
+	builder := tx.NewTxBuilder(protocol.Protocol{}, nil)
+	assert.ErrorIs(t, builder.SetMetadata(tx.TransactionMetadata{0: invalid}), tx.ErrMetadatumTooLong)
+}
+
+func TestMetadataJSON(t *testing.T) {
+	noSchema := `{"1":{"0x0102":[1,-2,"text"],"10":"0xcafe","name":{"a":"b"}},"674":"hello"}`
+	detailed := `{"1":{"map":[{"k":{"bytes":"0102"},"v":{"list":[{"int":1},{"int":-2},{"string":"text"}]}},` +
+		`{"k":{"int":10},"v":{"bytes":"cafe"}},{"k":{"string":"name"},"v":{"map":[{"k":{"string":"a"},"v":{"string":"b"}}]}}]},` +
+		`"674":{"string":"hello"}}`
+
+	fromNoSchema, err := tx.NewTransactionMetadataFromJSON([]byte(noSchema), tx.NoSchema)
+	assert.NoError(t, err)
+	fromDetailed, err := tx.NewTransactionMetadataFromJSON([]byte(detailed), tx.DetailedSchema)
+	assert.NoError(t, err)
+	assert.Equal(t, fromNoSchema, fromDetailed)
+
+	data, err := fromNoSchema.JSON(tx.NoSchema)
+	assert.NoError(t, err)
+	assert.Equal(t, noSchema, string(data))
+	data, err = fromNoSchema.JSON(tx.DetailedSchema)
+	assert.NoError(t, err)
+	assert.Equal(t, detailed, string(data))
+
+	invalid := []struct {
+		json   string
+		schema tx.MetadataJSONSchema
+	}{
+		{`{"1": 1.5}`, tx.NoSchema},
+		{`{"1": true}`, tx.NoSchema},
+		{`{"label": 1}`, tx.NoSchema},
+		{`{"1": "` + strings.Repeat("a", 65) + `"}`, tx.NoSchema},
+		{`{"1": {"int": 1, "string": "a"}}`, tx.DetailedSchema},
+		{`{"1": {"bytes": "0x01"}}`, tx.DetailedSchema},
+		{`{"1": {"map": [{"k": {"int": 1}}]}}`, tx.DetailedSchema},
+	}
+	for _, sc := range invalid {
+		_, err := tx.NewTransactionMetadataFromJSON([]byte(sc.json), sc.schema)
+		assert.Error(t, err, sc.json)
+	}
+}
@@ -0,0 +1,226 @@
+package tx
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+)
+
+// CIP25Label is the metadata label of CIP-25 NFT metadata.
+const CIP25Label = 721
+
+// CIP25Version is the version of the CIP-25 metadata format.
+type CIP25Version uint8
+
+const (
+	// CIP25Version1 keys policy ids and asset names by text.
+	CIP25Version1 CIP25Version = iota + 1
+	// CIP25Version2 keys policy ids and asset names by their raw bytes.
+	CIP25Version2
+)
+
+var (
+	ErrInvalidNFTMetadata = errors.New("invalid nft metadata")
+	ErrDuplicateNFT       = errors.New("nft metadata already added for the asset")
+)
+
+// NFTFile is a file of an NFT, e.g. a high resolution version of its image.
+type NFTFile struct {
+	Name      string
+	MediaType string
+	Src       string
+}
+
+// NFTMetadata contains the properties of an NFT shared by CIP-25 and CIP-68.
+// In CIP-25 metadata, uris and descriptions longer than 64 bytes are split into lists of chunks.
+type NFTMetadata struct {
+	Name        string
+	Image       string
+	MediaType   string
+	Description string
+	Files       []NFTFile
+
+	// Extra contains additional properties keyed by name.
+	Extra map[string]*TransactionMetadatum
+}
+
+// reservedNFTProperties are the properties set from the fields of NFTMetadata.
+var reservedNFTProperties = map[string]bool{
+	"name": true, "image": true, "mediaType": true, "description": true, "files": true,
+}
+
+// isURI reports whether s has the form of a uri with a scheme, e.g. `ipfs://...` or `data:...`.
+func isURI(s string) bool {
+	i := strings.Index(s, ":")
+	return i > 0 && !strings.ContainsAny(s[:i], " /")
+}
+
+// Validate checks that the required properties are set and well formed.
+func (n *NFTMetadata) Validate() error {
+	switch {
+	case n.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidNFTMetadata)
+	case len(n.Name) > MetadatumMaxLen:
+		return fmt.Errorf("%w: name is longer than 64 bytes", ErrInvalidNFTMetadata)
+	case !isURI(n.Image):
+		return fmt.Errorf("%w: image must be a uri", ErrInvalidNFTMetadata)
+	case n.MediaType != "" && !strings.HasPrefix(n.MediaType, "image/"):
+		return fmt.Errorf("%w: media type of the image must be image/*", ErrInvalidNFTMetadata)
+	case len(n.MediaType) > MetadatumMaxLen:
+		return fmt.Errorf("%w: media type is longer than 64 bytes", ErrInvalidNFTMetadata)
+	}
+
+	for i, file := range n.Files {
+		switch {
+		case len(file.Name) > MetadatumMaxLen:
+			return fmt.Errorf("%w: name of file %d is longer than 64 bytes", ErrInvalidNFTMetadata, i)
+		case file.MediaType == "" || len(file.MediaType) > MetadatumMaxLen:
+			return fmt.Errorf("%w: file %d requires a media type of at most 64 bytes", ErrInvalidNFTMetadata, i)
+		case !isURI(file.Src):
+			return fmt.Errorf("%w: src of file %d must be a uri", ErrInvalidNFTMetadata, i)
+		}
+	}
+
+	for key, value := range n.Extra {
+		if reservedNFTProperties[key] {
+			return fmt.Errorf("%w: extra property %s is reserved", ErrInvalidNFTMetadata, key)
+		}
+		if err := value.Validate(); err != nil {
+			return fmt.Errorf("%w: extra property %s: %s", ErrInvalidNFTMetadata, key, err)
+		}
+	}
+
+	return nil
+}
+
+// metadatum returns the properties as a map metadatum. Keys and texts are created by key and text,
+// which differ between CIP-25 and CIP-68.
+func (n *NFTMetadata) metadatum(key, text func(string) *TransactionMetadatum) *TransactionMetadatum {
+	properties := NewMapMetadatum(
+		MetadatumEntry{Key: key("name"), Value: text(n.Name)},
+		MetadatumEntry{Key: key("image"), Value: text(n.Image)},
+	)
+	if n.MediaType != "" {
+		properties.Map = append(properties.Map, MetadatumEntry{Key: key("mediaType"), Value: text(n.MediaType)})
+	}
+	if n.Description != "" {
+		properties.Map = append(properties.Map, MetadatumEntry{Key: key("description"), Value: text(n.Description)})
+	}
+	if len(n.Files) > 0 {
+		files := NewListMetadatum()
+		for _, file := range n.Files {
+			details := NewMapMetadatum()
+			if file.Name != "" {
+				details.Map = append(details.Map, MetadatumEntry{Key: key("name"), Value: text(file.Name)})
+			}
+			details.Map = append(details.Map,
+				MetadatumEntry{Key: key("mediaType"), Value: text(file.MediaType)},
+				MetadatumEntry{Key: key("src"), Value: text(file.Src)},
+			)
+			files.List = append(files.List, details)
+		}
+		properties.Map = append(properties.Map, MetadatumEntry{Key: key("files"), Value: files})
+	}
+
+	extra := make([]string, 0, len(n.Extra))
+	for k := range n.Extra {
+		extra = append(extra, k)
+	}
+	sort.Strings(extra)
+	for _, k := range extra {
+		properties.Map = append(properties.Map, MetadatumEntry{Key: key(k), Value: n.Extra[k]})
+	}
+
+	return properties
+}
+
+type cip25Asset struct {
+	name     AssetName
+	metadata *NFTMetadata
+}
+
+type cip25Policy struct {
+	id     crypto.ScriptHash
+	assets []cip25Asset
+}
+
+// CIP25Builder builds the label 721 metadata of NFTs minted in a transaction.
+type CIP25Builder struct {
+	version  CIP25Version
+	policies []*cip25Policy
+}
+
+// NewCIP25Builder returns a pointer to a new CIP25Builder of the version.
+func NewCIP25Builder(version CIP25Version) *CIP25Builder {
+	return &CIP25Builder{version: version}
+}
+
+// AddAsset adds the metadata of the asset minted under the policy. Version 1 requires utf-8 asset names.
+func (b *CIP25Builder) AddAsset(policyId crypto.ScriptHash, name AssetName, metadata *NFTMetadata) error {
+	if len(name) > AssetNameMaxLen {
+		return ErrInvalidAssetName
+	}
+	if b.version == CIP25Version1 && !utf8.Valid(name) {
+		return fmt.Errorf("%w: version 1 asset names must be utf-8", ErrInvalidNFTMetadata)
+	}
+	if err := metadata.Validate(); err != nil {
+		return err
+	}
+
+	var policy *cip25Policy
+	for _, p := range b.policies {
+		if p.id == policyId {
+			policy = p
+		}
+	}
+	if policy == nil {
+		policy = &cip25Policy{id: policyId}
+		b.policies = append(b.policies, policy)
+	}
+
+	for _, asset := range policy.assets {
+		if string(asset.name) == string(name) {
+			return ErrDuplicateNFT
+		}
+	}
+	policy.assets = append(policy.assets, cip25Asset{name: name, metadata: metadata})
+
+	return nil
+}
+
+// Build returns the transaction metadata with the NFT metadata under label 721.
+func (b *CIP25Builder) Build() (TransactionMetadata, error) {
+	if b.version != CIP25Version1 && b.version != CIP25Version2 {
+		return nil, fmt.Errorf("%w: unknown version %d", ErrInvalidNFTMetadata, b.version)
+	}
+
+	policies := NewMapMetadatum()
+	for _, policy := range b.policies {
+		assets := NewMapMetadatum()
+		for _, asset := range policy.assets {
+			key := &TransactionMetadatum{Kind: BytesMetadatum, Bytes: asset.name}
+			if b.version == CIP25Version1 {
+				key = &TransactionMetadatum{Kind: TextMetadatum, Text: string(asset.name)}
+			}
+			assets.Map = append(assets.Map, MetadatumEntry{Key: key, Value: asset.metadata.metadatum(NewTextMetadatum, NewTextMetadatum)})
+		}
+
+		key := &TransactionMetadatum{Kind: BytesMetadatum, Bytes: policy.id[:]}
+		if b.version == CIP25Version1 {
+			key = NewTextMetadatum(hex.EncodeToString(policy.id[:]))
+		}
+		policies.Map = append(policies.Map, MetadatumEntry{Key: key, Value: assets})
+	}
+
+	if b.version == CIP25Version2 {
+		policies.Map = append(policies.Map, MetadatumEntry{Key: NewTextMetadatum("version"), Value: NewTextMetadatum("2.0")})
+	}
+
+	metadata := TransactionMetadata{CIP25Label: policies}
+	return metadata, metadata.Validate()
+}
@@ -0,0 +1,92 @@
+package tx_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fivebinaries/go-cardano-serialization/tx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCIP25Metadata(t *testing.T) {
+	policyId, _ := crypto.ScriptHashFromBytes(bytes.Repeat([]byte{0xab}, 28))
+	policyHex := hex.EncodeToString(policyId[:])
+	longImage := "ipfs://" + strings.Repeat("a", 60)
+	nft := &tx.NFTMetadata{
+		Name:      "My NFT",
+		Image:     longImage,
+		MediaType: "image/png",
+		Files:     []tx.NFTFile{{Name: "full", MediaType: "video/mp4", Src: "ar://video"}},
+		Extra:     map[string]*tx.TransactionMetadatum{"artist": tx.NewTextMetadatum("me")},
+	}
+
+	builder := tx.NewCIP25Builder(tx.CIP25Version1)
+	assert.NoError(t, builder.AddAsset(policyId, tx.AssetName("MyNFT"), nft))
+	assert.ErrorIs(t, builder.AddAsset(policyId, tx.AssetName("MyNFT"), nft), tx.ErrDuplicateNFT)
+	assert.ErrorIs(t, builder.AddAsset(policyId, tx.AssetName{0xff}, nft), tx.ErrInvalidNFTMetadata)
+	metadata, err := builder.Build()
+	assert.NoError(t, err)
+	data, err := metadata.JSON(tx.NoSchema)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"721":{"`+policyHex+`":{"MyNFT":{"name":"My NFT","image":["ipfs://`+strings.Repeat("a", 57)+`","aaa"],`+
+		`"mediaType":"image/png","files":[{"name":"full","mediaType":"video/mp4","src":"ar://video"}],"artist":"me"}}}}`, string(data))
+
+	builder = tx.NewCIP25Builder(tx.CIP25Version2)
+	assert.NoError(t, builder.AddAsset(policyId, tx.AssetName{0xff}, &tx.NFTMetadata{Name: "Bytes", Image: "ipfs://b"}))
+	metadata, err = builder.Build()
+	assert.NoError(t, err)
+	data, err = metadata.JSON(tx.NoSchema)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"721":{"0x`+policyHex+`":{"0xff":{"name":"Bytes","image":"ipfs://b"}},"version":"2.0"}}`, string(data))
+
+	invalid := []*tx.NFTMetadata{
+		{Image: "ipfs://b"},
+		{Name: "No image"},
+		{Name: "Bad media type", Image: "ipfs://b", MediaType: "video/mp4"},
+		{Name: "Bad file", Image: "ipfs://b", Files: []tx.NFTFile{{Src: "ipfs://c"}}},
+		{Name: "Reserved", Image: "ipfs://b", Extra: map[string]*tx.TransactionMetadatum{"name": tx.NewTextMetadatum("x")}},
+	}
+	for _, nft := range invalid {
+		assert.ErrorIs(t, nft.Validate(), tx.ErrInvalidNFTMetadata, nft.Name)
+	}
+}
+
+func TestCIP68Metadata(t *testing.T) {
+	for label, prefix := range map[uint16]string{
+		tx.CIP68ReferenceLabel: "000643b0",
+		tx.CIP68NFTLabel:       "000de140",
+		tx.CIP68FTLabel:        "0014df10",
+		tx.CIP68RFTLabel:       "001bc280",
+	} {
+		name, err := tx.NewCIP67AssetName(label, []byte("A"))
+		assert.NoError(t, err)
+		assert.Equal(t, prefix+"41", name.String())
+
+		parsedLabel, content, err := tx.ParseCIP67AssetName(name)
+		assert.NoError(t, err)
+		assert.Equal(t, label, parsedLabel)
+		assert.Equal(t, []byte("A"), content)
+	}
+	_, _, err := tx.ParseCIP67AssetName(tx.AssetName{0x00, 0x06, 0x43, 0xa0})
+	assert.ErrorIs(t, err, tx.ErrInvalidCIP67Label)
+
+	token, err := tx.NewCIP68NFT([]byte("A"), &tx.NFTMetadata{Name: "A", Image: "ipfs://b"})
+	assert.NoError(t, err)
+	assert.Equal(t, "000643b041", token.Reference.String())
+	assert.Equal(t, "000de14041", token.User.String())
+
+	datum, err := token.Datum.MarshalCBOR()
+	assert.NoError(t, err)
+	assert.Equal(t, "d87983a2446e616d65414145696d61676548697066733a2f2f6201d87980", hex.EncodeToString(datum))
+
+	// Long uris are a single byte string split into 64 byte chunks
+	long := "ipfs://" + strings.Repeat("a", 60)
+	token, err = tx.NewCIP68NFT([]byte("A"), &tx.NFTMetadata{Name: "A", Image: long})
+	assert.NoError(t, err)
+	datum, err = token.Datum.MarshalCBOR()
+	assert.NoError(t, err)
+	assert.Contains(t, hex.EncodeToString(datum), "5f5840"+hex.EncodeToString([]byte(long[:64]))+"43"+hex.EncodeToString([]byte(long[64:]))+"ff")
+}
@@ -0,0 +1,161 @@
+package tx
+
+import (
+	"github.com/fxamacker/cbor/v2"
+)
+
+// NonNegativeInterval is a non negative rational number, e.g. a pool pledge influence or execution unit price.
+type NonNegativeInterval struct {
+	Numerator   uint64
+	Denominator uint64
+}
+
+// MarshalCBOR returns the cbor encoding of the interval as a rational number (tag 30).
+func (n NonNegativeInterval) MarshalCBOR() ([]byte, error) {
+	if n.Denominator == 0 {
+		return nil, ErrInvalidUnitInterval
+	}
+	return cbor.Marshal(cbor.Tag{
+		Number:  30,
+		Content: []uint64{n.Numerator, n.Denominator},
+	})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded rational number into the interval.
+func (n *NonNegativeInterval) UnmarshalCBOR(data []byte) error {
+	var raw []uint64
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 2 || raw[1] == 0 {
+		return ErrInvalidUnitInterval
+	}
+	n.Numerator, n.Denominator = raw[0], raw[1]
+	return nil
+}
+
+// ExUnits is an amount of plutus execution units.
+type ExUnits struct {
+	_     struct{} `cbor:",toarray"`
+	Mem   uint64
+	Steps uint64
+}
+
+// ExUnitPrices contains the prices of plutus execution units in lovelace.
+type ExUnitPrices struct {
+	_     struct{} `cbor:",toarray"`
+	Mem   NonNegativeInterval
+	Steps NonNegativeInterval
+}
+
+// PoolVotingThresholds contains the stake pool operator voting thresholds.
+type PoolVotingThresholds struct {
+	_                     struct{} `cbor:",toarray"`
+	MotionNoConfidence    UnitInterval
+	CommitteeNormal       UnitInterval
+	CommitteeNoConfidence UnitInterval
+	HardForkInitiation    UnitInterval
+	PPSecurityGroup       UnitInterval
+}
+
+// DRepVotingThresholds contains the delegate representative voting thresholds.
+type DRepVotingThresholds struct {
+	_                     struct{} `cbor:",toarray"`
+	MotionNoConfidence    UnitInterval
+	CommitteeNormal       UnitInterval
+	CommitteeNoConfidence UnitInterval
+	UpdateConstitution    UnitInterval
+	HardForkInitiation    UnitInterval
+	PPNetworkGroup        UnitInterval
+	PPEconomicGroup       UnitInterval
+	PPTechnicalGroup      UnitInterval
+	PPGovGroup            UnitInterval
+	TreasuryWithdrawal    UnitInterval
+}
+
+// Plutus language versions used as keys of the cost models.
+const (
+	PlutusV1 uint = iota
+	PlutusV2
+	PlutusV3
+)
+
+// ProtocolParamUpdate contains the protocol parameters changed by a parameter change governance action.
+// Only the parameters which are set are updated.
+type ProtocolParamUpdate struct {
+	MinFeeA                    *uint64               `cbor:"0,keyasint,omitempty"`
+	MinFeeB                    *uint64               `cbor:"1,keyasint,omitempty"`
+	MaxBlockBodySize           *uint64               `cbor:"2,keyasint,omitempty"`
+	MaxTxSize                  *uint64               `cbor:"3,keyasint,omitempty"`
+	MaxBlockHeaderSize         *uint64               `cbor:"4,keyasint,omitempty"`
+	KeyDeposit                 *uint64               `cbor:"5,keyasint,omitempty"`
+	PoolDeposit                *uint64               `cbor:"6,keyasint,omitempty"`
+	MaxEpoch                   *uint64               `cbor:"7,keyasint,omitempty"`
+	NOpt                       *uint64               `cbor:"8,keyasint,omitempty"`
+	PoolPledgeInfluence        *NonNegativeInterval  `cbor:"9,keyasint,omitempty"`
+	ExpansionRate              *UnitInterval         `cbor:"10,keyasint,omitempty"`
+	TreasuryGrowthRate         *UnitInterval         `cbor:"11,keyasint,omitempty"`
+	MinPoolCost                *uint64               `cbor:"16,keyasint,omitempty"`
+	AdaPerUTxOByte             *uint64               `cbor:"17,keyasint,omitempty"`
+	CostModels                 map[uint][]int64      `cbor:"18,keyasint,omitempty"`
+	ExecutionCosts             *ExUnitPrices         `cbor:"19,keyasint,omitempty"`
+	MaxTxExUnits               *ExUnits              `cbor:"20,keyasint,omitempty"`
+	MaxBlockExUnits            *ExUnits              `cbor:"21,keyasint,omitempty"`
+	MaxValueSize               *uint64               `cbor:"22,keyasint,omitempty"`
+	CollateralPercentage       *uint64               `cbor:"23,keyasint,omitempty"`
+	MaxCollateralInputs        *uint64               `cbor:"24,keyasint,omitempty"`
+	PoolVotingThresholds       *PoolVotingThresholds `cbor:"25,keyasint,omitempty"`
+	DRepVotingThresholds       *DRepVotingThresholds `cbor:"26,keyasint,omitempty"`
+	MinCommitteeSize           *uint64               `cbor:"27,keyasint,omitempty"`
+	CommitteeTermLimit         *uint64               `cbor:"28,keyasint,omitempty"`
+	GovActionValidityPeriod    *uint64               `cbor:"29,keyasint,omitempty"`
+	GovActionDeposit           *uint64               `cbor:"30,keyasint,omitempty"`
+	DRepDeposit                *uint64               `cbor:"31,keyasint,omitempty"`
+	DRepInactivityPeriod       *uint64               `cbor:"32,keyasint,omitempty"`
+	MinFeeRefScriptCostPerByte *NonNegativeInterval  `cbor:"33,keyasint,omitempty"`
+}
+
+// costModelsKey is the key of the cost models in the cbor map of a protocol parameter update.
+const costModelsKey = 18
+
+// MarshalCBOR returns the cbor encoding of the protocol parameter update. The cost models are encoded
+// with the language versions in canonical order, so the encoding does not depend on map iteration order.
+func (p ProtocolParamUpdate) MarshalCBOR() ([]byte, error) {
+	type paramUpdate ProtocolParamUpdate
+	costModels := p.CostModels
+	p.CostModels = nil
+	data, err := cbor.Marshal(paramUpdate(p))
+	if err != nil || len(costModels) == 0 {
+		return data, err
+	}
+
+	keys, values, err := unmarshalMap(data)
+	if err != nil {
+		return nil, err
+	}
+	var langs, models [][]byte
+	for lang, model := range costModels {
+		langBytes, err := cbor.Marshal(lang)
+		if err != nil {
+			return nil, err
+		}
+		modelBytes, err := cbor.Marshal(model)
+		if err != nil {
+			return nil, err
+		}
+		langs = append(langs, langBytes)
+		models = append(models, modelBytes)
+	}
+	key, err := cbor.Marshal(uint(costModelsKey))
+	if err != nil {
+		return nil, err
+	}
+
+	rawKeys := [][]byte{key}
+	rawValues := [][]byte{marshalMap(langs, models)}
+	for i := range keys {
+		rawKeys = append(rawKeys, keys[i])
+		rawValues = append(rawValues, values[i])
+	}
+	return marshalMap(rawKeys, rawValues), nil
+}
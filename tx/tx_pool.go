@@ -0,0 +1,410 @@
+package tx
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+
+	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	maxDNSNameLen     = 64
+	maxMetadataURLLen = 64
+)
+
+var (
+	ErrInvalidRelay         = errors.New("invalid pool relay")
+	ErrInvalidPoolMetadata  = errors.New("invalid pool metadata")
+	ErrMissingRewardAccount = errors.New("missing pool reward account")
+)
+
+// RelayType is the tag identifying the kind of a relay in its cbor encoding.
+type RelayType uint
+
+const (
+	SingleHostAddrRelayType RelayType = iota
+	SingleHostNameRelayType
+	MultiHostNameRelayType
+)
+
+// Relay is a stake pool relay announced in a pool registration certificate.
+type Relay interface {
+	cbor.Marshaler
+
+	// Type returns the relay type
+	Type() RelayType
+}
+
+// SingleHostAddr is a relay reachable on an IPv4 and/or IPv6 address. A zero port is left unspecified.
+type SingleHostAddr struct {
+	Port uint16
+	IPv4 net.IP
+	IPv6 net.IP
+}
+
+// NewSingleHostAddr returns a pointer to a new SingleHostAddr relay.
+func NewSingleHostAddr(port uint16, ipv4, ipv6 net.IP) (*SingleHostAddr, error) {
+	r := &SingleHostAddr{Port: port, IPv4: ipv4, IPv6: ipv6}
+	if ipv4 == nil && ipv6 == nil {
+		return nil, ErrInvalidRelay
+	}
+	if ipv4 != nil && ipv4.To4() == nil {
+		return nil, ErrInvalidRelay
+	}
+	if ipv6 != nil && (ipv6.To16() == nil || ipv6.To4() != nil) {
+		return nil, ErrInvalidRelay
+	}
+	return r, nil
+}
+
+// Type returns SingleHostAddrRelayType.
+func (r *SingleHostAddr) Type() RelayType {
+	return SingleHostAddrRelayType
+}
+
+// MarshalCBOR returns the cbor encoding of the relay. The IPv6 address is encoded as four
+// 32 bit words in little endian byte order, matching the encoding of the ledger.
+func (r *SingleHostAddr) MarshalCBOR() ([]byte, error) {
+	var ipv4, ipv6 interface{}
+	if r.IPv4 != nil {
+		ipv4 = []byte(r.IPv4.To4())
+	}
+	if r.IPv6 != nil {
+		ip := r.IPv6.To16()
+		encoded := make([]byte, net.IPv6len)
+		for i := 0; i < net.IPv6len; i += 4 {
+			binary.LittleEndian.PutUint32(encoded[i:], binary.BigEndian.Uint32(ip[i:]))
+		}
+		ipv6 = encoded
+	}
+	return cbor.Marshal([]interface{}{r.Type(), relayPort(r.Port), ipv4, ipv6})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded single host address relay.
+func (r *SingleHostAddr) UnmarshalCBOR(data []byte) error {
+	var (
+		port       *uint16
+		ipv4, ipv6 []byte
+	)
+	if err := unmarshalTypedArray(data, uint(SingleHostAddrRelayType), ErrInvalidRelay, &port, &ipv4, &ipv6); err != nil {
+		return err
+	}
+
+	relay := SingleHostAddr{}
+	if port != nil {
+		relay.Port = *port
+	}
+	if ipv4 != nil {
+		if len(ipv4) != net.IPv4len {
+			return ErrInvalidRelay
+		}
+		relay.IPv4 = net.IPv4(ipv4[0], ipv4[1], ipv4[2], ipv4[3])
+	}
+	if ipv6 != nil {
+		if len(ipv6) != net.IPv6len {
+			return ErrInvalidRelay
+		}
+		relay.IPv6 = make(net.IP, net.IPv6len)
+		for i := 0; i < net.IPv6len; i += 4 {
+			binary.BigEndian.PutUint32(relay.IPv6[i:], binary.LittleEndian.Uint32(ipv6[i:]))
+		}
+	}
+	*r = relay
+	return nil
+}
+
+// SingleHostName is a relay reachable on the A or AAAA records of a dns name.
+type SingleHostName struct {
+	Port    uint16
+	DNSName string
+}
+
+// NewSingleHostName returns a pointer to a new SingleHostName relay.
+func NewSingleHostName(port uint16, dnsName string) (*SingleHostName, error) {
+	if dnsName == "" || len(dnsName) > maxDNSNameLen {
+		return nil, ErrInvalidRelay
+	}
+	return &SingleHostName{Port: port, DNSName: dnsName}, nil
+}
+
+// Type returns SingleHostNameRelayType.
+func (r *SingleHostName) Type() RelayType {
+	return SingleHostNameRelayType
+}
+
+// MarshalCBOR returns the cbor encoding of the relay.
+func (r *SingleHostName) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{r.Type(), relayPort(r.Port), r.DNSName})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded single host name relay.
+func (r *SingleHostName) UnmarshalCBOR(data []byte) error {
+	var port *uint16
+	relay := SingleHostName{}
+	if err := unmarshalTypedArray(data, uint(SingleHostNameRelayType), ErrInvalidRelay, &port, &relay.DNSName); err != nil {
+		return err
+	}
+	if port != nil {
+		relay.Port = *port
+	}
+	*r = relay
+	return nil
+}
+
+// MultiHostName is a set of relays reachable on the SRV records of a dns name.
+type MultiHostName struct {
+	DNSName string
+}
+
+// NewMultiHostName returns a pointer to a new MultiHostName relay.
+func NewMultiHostName(dnsName string) (*MultiHostName, error) {
+	if dnsName == "" || len(dnsName) > maxDNSNameLen {
+		return nil, ErrInvalidRelay
+	}
+	return &MultiHostName{DNSName: dnsName}, nil
+}
+
+// Type returns MultiHostNameRelayType.
+func (r *MultiHostName) Type() RelayType {
+	return MultiHostNameRelayType
+}
+
+// MarshalCBOR returns the cbor encoding of the relay.
+func (r *MultiHostName) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{r.Type(), r.DNSName})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded multi host name relay.
+func (r *MultiHostName) UnmarshalCBOR(data []byte) error {
+	relay := MultiHostName{}
+	if err := unmarshalTypedArray(data, uint(MultiHostNameRelayType), ErrInvalidRelay, &relay.DNSName); err != nil {
+		return err
+	}
+	*r = relay
+	return nil
+}
+
+// unmarshalRelay deserializes a cbor encoded relay of any type.
+func unmarshalRelay(data []byte) (Relay, error) {
+	var raw []cbor.RawMessage
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return nil, ErrInvalidRelay
+	}
+	var typ RelayType
+	if err := cbor.Unmarshal(raw[0], &typ); err != nil {
+		return nil, err
+	}
+
+	var relay Relay
+	switch typ {
+	case SingleHostAddrRelayType:
+		relay = &SingleHostAddr{}
+	case SingleHostNameRelayType:
+		relay = &SingleHostName{}
+	case MultiHostNameRelayType:
+		relay = &MultiHostName{}
+	default:
+		return nil, ErrInvalidRelay
+	}
+
+	if err := cbor.Unmarshal(data, relay); err != nil {
+		return nil, err
+	}
+	return relay, nil
+}
+
+func relayPort(port uint16) interface{} {
+	if port == 0 {
+		return nil
+	}
+	return port
+}
+
+// PoolMetadata references the off-chain metadata of a stake pool by url and hash.
+type PoolMetadata struct {
+	URL  string
+	Hash crypto.MetadataHash
+}
+
+// NewPoolMetadata returns a pointer to a new PoolMetadata given the url and hash of the metadata.
+func NewPoolMetadata(url string, hash crypto.MetadataHash) (*PoolMetadata, error) {
+	if url == "" || len(url) > maxMetadataURLLen {
+		return nil, ErrInvalidPoolMetadata
+	}
+	return &PoolMetadata{URL: url, Hash: hash}, nil
+}
+
+// MarshalCBOR returns the cbor encoding of the pool metadata.
+func (m *PoolMetadata) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{m.URL, m.Hash[:]})
+}
+
+// UnmarshalCBOR deserializes cbor encoded pool metadata.
+func (m *PoolMetadata) UnmarshalCBOR(data []byte) error {
+	metadata := PoolMetadata{}
+	if err := unmarshalArray(data, ErrInvalidPoolMetadata, &metadata.URL, fixed(metadata.Hash[:])); err != nil {
+		return err
+	}
+	if metadata.URL == "" || len(metadata.URL) > maxMetadataURLLen {
+		return ErrInvalidPoolMetadata
+	}
+	*m = metadata
+	return nil
+}
+
+// PoolRegistration is a certificate registering a new stake pool or updating the parameters of a registered one.
+type PoolRegistration struct {
+	Operator      crypto.Ed25519KeyHash
+	VRFKeyHash    crypto.VRFKeyHash
+	Pledge        uint64
+	Cost          uint64
+	Margin        UnitInterval
+	RewardAccount *address.RewardAddress
+	Owners        []crypto.Ed25519KeyHash
+	Relays        []Relay
+	Metadata      *PoolMetadata
+}
+
+// Type returns PoolRegistrationCertificateType.
+func (p *PoolRegistration) Type() CertificateType {
+	return PoolRegistrationCertificateType
+}
+
+// Validate checks the margin, reward account and metadata of the pool registration.
+func (p *PoolRegistration) Validate() error {
+	if err := p.Margin.Validate(); err != nil {
+		return err
+	}
+	if p.RewardAccount == nil {
+		return ErrMissingRewardAccount
+	}
+	if p.Metadata != nil && (p.Metadata.URL == "" || len(p.Metadata.URL) > maxMetadataURLLen) {
+		return ErrInvalidPoolMetadata
+	}
+	return nil
+}
+
+// MarshalCBOR returns the cbor encoding of the pool registration certificate.
+func (p *PoolRegistration) MarshalCBOR() ([]byte, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	owners := make([][]byte, 0, len(p.Owners))
+	for i := range p.Owners {
+		owners = append(owners, p.Owners[i][:])
+	}
+
+	relays := p.Relays
+	if relays == nil {
+		relays = []Relay{}
+	}
+
+	var metadata interface{}
+	if p.Metadata != nil {
+		metadata = p.Metadata
+	}
+
+	return cbor.Marshal([]interface{}{
+		p.Type(),
+		p.Operator[:],
+		p.VRFKeyHash[:],
+		p.Pledge,
+		p.Cost,
+		p.Margin,
+		p.RewardAccount.Bytes(),
+		owners,
+		relays,
+		metadata,
+	})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded pool registration certificate.
+func (p *PoolRegistration) UnmarshalCBOR(data []byte) error {
+	var (
+		rewardAccount []byte
+		owners        [][]byte
+		relays        []cbor.RawMessage
+	)
+	cert := PoolRegistration{}
+	err := unmarshalTypedArray(data, uint(PoolRegistrationCertificateType), ErrInvalidCertificate,
+		fixed(cert.Operator[:]), fixed(cert.VRFKeyHash[:]), &cert.Pledge, &cert.Cost, &cert.Margin,
+		&rewardAccount, &owners, &relays, &cert.Metadata)
+	if err != nil {
+		return err
+	}
+
+	if cert.RewardAccount, err = rewardAccountFromBytes(rewardAccount); err != nil {
+		return err
+	}
+	for _, owner := range owners {
+		keyHash, err := crypto.Ed25519KeyHashFromBytes(owner)
+		if err != nil {
+			return err
+		}
+		cert.Owners = append(cert.Owners, keyHash)
+	}
+	for _, rawRelay := range relays {
+		relay, err := unmarshalRelay(rawRelay)
+		if err != nil {
+			return err
+		}
+		cert.Relays = append(cert.Relays, relay)
+	}
+
+	*p = cert
+	return nil
+}
+
+// PoolRetirement is a certificate announcing the retirement of a stake pool at the start of an epoch.
+type PoolRetirement struct {
+	Operator crypto.Ed25519KeyHash
+	Epoch    uint64
+}
+
+// NewPoolRetirement returns a pointer to a new PoolRetirement certificate given the pool operator key hash and epoch.
+func NewPoolRetirement(operator crypto.Ed25519KeyHash, epoch uint64) *PoolRetirement {
+	return &PoolRetirement{
+		Operator: operator,
+		Epoch:    epoch,
+	}
+}
+
+// Type returns PoolRetirementCertificateType.
+func (p *PoolRetirement) Type() CertificateType {
+	return PoolRetirementCertificateType
+}
+
+// MarshalCBOR returns the cbor encoding of the pool retirement certificate.
+func (p *PoolRetirement) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{p.Type(), p.Operator[:], p.Epoch})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded pool retirement certificate.
+func (p *PoolRetirement) UnmarshalCBOR(data []byte) error {
+	cert := PoolRetirement{}
+	if err := unmarshalTypedArray(data, uint(PoolRetirementCertificateType), ErrInvalidCertificate, fixed(cert.Operator[:]), &cert.Epoch); err != nil {
+		return err
+	}
+	*p = cert
+	return nil
+}
+
+// rewardAccountFromBytes returns the reward address of the raw bytes of a reward account.
+func rewardAccountFromBytes(data []byte) (*address.RewardAddress, error) {
+	addr, err := address.NewAddressFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	rewardAccount, ok := addr.(*address.RewardAddress)
+	if !ok {
+		return nil, address.ErrUnsupportedAddress
+	}
+	return rewardAccount, nil
+}
@@ -0,0 +1,109 @@
+package tx
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+)
+
+const (
+	maxPoolMetadataSize      = 512
+	maxPoolNameLen           = 50
+	maxPoolDescriptionLen    = 255
+	minPoolTickerLen         = 3
+	maxPoolTickerLen         = 5
+	maxPoolHomepageLen       = 64
+	maxPoolExtendedURLLength = 64
+)
+
+var (
+	ErrPoolMetadataTooLarge     = errors.New("pool metadata exceeds 512 bytes")
+	ErrInvalidPoolMetadataField = errors.New("invalid pool metadata field")
+	ErrMissingPoolMetadata      = errors.New("pool registration has no metadata")
+	ErrPoolMetadataHashMismatch = errors.New("pool metadata hash mismatch")
+)
+
+// PoolMetadataContent is the off-chain stake pool metadata referenced by a pool registration certificate.
+type PoolMetadataContent struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Ticker      string `json:"ticker"`
+	Homepage    string `json:"homepage"`
+	Extended    string `json:"extended,omitempty"`
+}
+
+// ParsePoolMetadata returns a pointer to the PoolMetadataContent unmarshalled from the raw metadata
+// file after checking the size limit and the lengths of its fields.
+func ParsePoolMetadata(data []byte) (*PoolMetadataContent, error) {
+	if len(data) > maxPoolMetadataSize {
+		return nil, ErrPoolMetadataTooLarge
+	}
+
+	content := &PoolMetadataContent{}
+	if err := json.Unmarshal(data, content); err != nil {
+		return nil, err
+	}
+	if err := content.Validate(); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// Validate checks the required fields of the metadata and their lengths.
+func (c *PoolMetadataContent) Validate() error {
+	fields := []struct {
+		name     string
+		value    string
+		min, max int
+	}{
+		{"name", c.Name, 1, maxPoolNameLen},
+		{"description", c.Description, 0, maxPoolDescriptionLen},
+		{"ticker", c.Ticker, minPoolTickerLen, maxPoolTickerLen},
+		{"homepage", c.Homepage, 0, maxPoolHomepageLen},
+		{"extended", c.Extended, 0, maxPoolExtendedURLLength},
+	}
+	for _, field := range fields {
+		length := utf8.RuneCountInString(field.value)
+		if length < field.min || length > field.max {
+			return fmt.Errorf("%w: %s must be %d to %d characters long", ErrInvalidPoolMetadataField, field.name, field.min, field.max)
+		}
+	}
+	return nil
+}
+
+// PoolMetadataHash returns the blake2b256 hash of the raw metadata file as referenced by pool registrations.
+func PoolMetadataHash(data []byte) crypto.MetadataHash {
+	return crypto.MetadataHash(crypto.Blake2b256(data))
+}
+
+// NewPoolMetadataFromContent returns a pointer to a new PoolMetadata for a pool registration given the url
+// the raw metadata file is hosted at. The file is validated before it is hashed.
+func NewPoolMetadataFromContent(url string, data []byte) (*PoolMetadata, error) {
+	if _, err := ParsePoolMetadata(data); err != nil {
+		return nil, err
+	}
+	return NewPoolMetadata(url, PoolMetadataHash(data))
+}
+
+// VerifyMetadata validates the raw metadata file and checks its hash against the metadata hash of the registration.
+func (p *PoolRegistration) VerifyMetadata(data []byte) error {
+	if p.Metadata == nil {
+		return ErrMissingPoolMetadata
+	}
+	if _, err := ParsePoolMetadata(data); err != nil {
+		return err
+	}
+	if hash := PoolMetadataHash(data); hash != p.Metadata.Hash {
+		return fmt.Errorf(
+			"%w: expected %s, got %s",
+			ErrPoolMetadataHashMismatch,
+			hex.EncodeToString(p.Metadata.Hash[:]),
+			hex.EncodeToString(hash[:]),
+		)
+	}
+	return nil
+}
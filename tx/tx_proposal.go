@@ -0,0 +1,466 @@
+package tx
+
+import (
+	"errors"
+
+	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fivebinaries/go-cardano-serialization/protocol"
+	"github.com/fxamacker/cbor/v2"
+)
+
+var (
+	ErrInvalidProposal = errors.New("invalid proposal procedure")
+)
+
+// GovActionType is the tag identifying the kind of a governance action in its cbor encoding.
+type GovActionType uint
+
+const (
+	ParameterChangeGovActionType GovActionType = iota
+	HardForkInitiationGovActionType
+	TreasuryWithdrawalsGovActionType
+	NoConfidenceGovActionType
+	UpdateCommitteeGovActionType
+	NewConstitutionGovActionType
+	InfoGovActionType
+)
+
+// GovAction is a governance action submitted in a proposal procedure.
+type GovAction interface {
+	cbor.Marshaler
+
+	// Type returns the governance action type
+	Type() GovActionType
+}
+
+// optionalScriptHash returns nil for a missing script hash so it is encoded as cbor null.
+func optionalScriptHash(hash *crypto.ScriptHash) interface{} {
+	if hash == nil {
+		return nil
+	}
+	return hash[:]
+}
+
+// unmarshalOptionalScriptHash deserializes a cbor encoded script hash, returning nil for cbor null.
+func unmarshalOptionalScriptHash(data []byte) (*crypto.ScriptHash, error) {
+	if isNull(data) {
+		return nil, nil
+	}
+	var hash crypto.ScriptHash
+	if err := cbor.Unmarshal(data, fixed(hash[:])); err != nil {
+		return nil, err
+	}
+	return &hash, nil
+}
+
+// unmarshalGovAction deserializes a cbor encoded governance action of any type.
+func unmarshalGovAction(data []byte) (GovAction, error) {
+	var raw []cbor.RawMessage
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return nil, ErrInvalidProposal
+	}
+	var typ GovActionType
+	if err := cbor.Unmarshal(raw[0], &typ); err != nil {
+		return nil, err
+	}
+
+	var action GovAction
+	switch typ {
+	case ParameterChangeGovActionType:
+		action = &ParameterChangeAction{}
+	case HardForkInitiationGovActionType:
+		action = &HardForkInitiationAction{}
+	case TreasuryWithdrawalsGovActionType:
+		action = &TreasuryWithdrawalsAction{}
+	case NoConfidenceGovActionType:
+		action = &NoConfidenceAction{}
+	case UpdateCommitteeGovActionType:
+		action = &UpdateCommitteeAction{}
+	case NewConstitutionGovActionType:
+		action = &NewConstitutionAction{}
+	case InfoGovActionType:
+		action = &InfoAction{}
+	default:
+		return nil, ErrInvalidProposal
+	}
+
+	if err := cbor.Unmarshal(data, action); err != nil {
+		return nil, err
+	}
+	return action, nil
+}
+
+// ParameterChangeAction proposes to update protocol parameters. PolicyHash is the hash of the guardrails script.
+type ParameterChangeAction struct {
+	PrevActionId *GovActionId
+	Update       ProtocolParamUpdate
+	PolicyHash   *crypto.ScriptHash
+}
+
+// Type returns ParameterChangeGovActionType.
+func (a *ParameterChangeAction) Type() GovActionType {
+	return ParameterChangeGovActionType
+}
+
+// MarshalCBOR returns the cbor encoding of the governance action.
+func (a *ParameterChangeAction) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{a.Type(), optionalGovActionId(a.PrevActionId), a.Update, optionalScriptHash(a.PolicyHash)})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded parameter change action.
+func (a *ParameterChangeAction) UnmarshalCBOR(data []byte) error {
+	var policyHash cbor.RawMessage
+	action := ParameterChangeAction{}
+	err := unmarshalTypedArray(data, uint(ParameterChangeGovActionType), ErrInvalidProposal, &action.PrevActionId, &action.Update, &policyHash)
+	if err != nil {
+		return err
+	}
+	if action.PolicyHash, err = unmarshalOptionalScriptHash(policyHash); err != nil {
+		return err
+	}
+	*a = action
+	return nil
+}
+
+// HardForkInitiationAction proposes to move to a new major protocol version.
+type HardForkInitiationAction struct {
+	PrevActionId    *GovActionId
+	ProtocolVersion protocol.ProtocolVersion
+}
+
+// Type returns HardForkInitiationGovActionType.
+func (a *HardForkInitiationAction) Type() GovActionType {
+	return HardForkInitiationGovActionType
+}
+
+// MarshalCBOR returns the cbor encoding of the governance action.
+func (a *HardForkInitiationAction) MarshalCBOR() ([]byte, error) {
+	version := []uint{uint(a.ProtocolVersion.Major), uint(a.ProtocolVersion.Minor)}
+	return cbor.Marshal([]interface{}{a.Type(), optionalGovActionId(a.PrevActionId), version})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded hard fork initiation action.
+func (a *HardForkInitiationAction) UnmarshalCBOR(data []byte) error {
+	var version struct {
+		_     struct{} `cbor:",toarray"`
+		Major uint8
+		Minor uint8
+	}
+	action := HardForkInitiationAction{}
+	if err := unmarshalTypedArray(data, uint(HardForkInitiationGovActionType), ErrInvalidProposal, &action.PrevActionId, &version); err != nil {
+		return err
+	}
+	action.ProtocolVersion = protocol.ProtocolVersion{Major: version.Major, Minor: version.Minor}
+	*a = action
+	return nil
+}
+
+// TreasuryWithdrawal is an amount of lovelace withdrawn from the treasury to a reward account.
+type TreasuryWithdrawal struct {
+	RewardAccount *address.RewardAddress
+	Amount        uint64
+}
+
+// TreasuryWithdrawalsAction proposes to withdraw funds from the treasury. PolicyHash is the hash of the guardrails script.
+type TreasuryWithdrawalsAction struct {
+	Withdrawals []TreasuryWithdrawal
+	PolicyHash  *crypto.ScriptHash
+}
+
+// Type returns TreasuryWithdrawalsGovActionType.
+func (a *TreasuryWithdrawalsAction) Type() GovActionType {
+	return TreasuryWithdrawalsGovActionType
+}
+
+// MarshalCBOR returns the cbor encoding of the governance action.
+func (a *TreasuryWithdrawalsAction) MarshalCBOR() ([]byte, error) {
+	var accounts, amounts [][]byte
+	for _, withdrawal := range a.Withdrawals {
+		if withdrawal.RewardAccount == nil {
+			return nil, ErrInvalidProposal
+		}
+		account, err := cbor.Marshal(withdrawal.RewardAccount.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		amount, err := cbor.Marshal(withdrawal.Amount)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+		amounts = append(amounts, amount)
+	}
+	return cbor.Marshal([]interface{}{
+		a.Type(),
+		cbor.RawMessage(marshalMap(accounts, amounts)),
+		optionalScriptHash(a.PolicyHash),
+	})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded treasury withdrawals action.
+func (a *TreasuryWithdrawalsAction) UnmarshalCBOR(data []byte) error {
+	var withdrawals, policyHash cbor.RawMessage
+	if err := unmarshalTypedArray(data, uint(TreasuryWithdrawalsGovActionType), ErrInvalidProposal, &withdrawals, &policyHash); err != nil {
+		return err
+	}
+	accounts, amounts, err := unmarshalMap(withdrawals)
+	if err != nil {
+		return err
+	}
+
+	action := TreasuryWithdrawalsAction{}
+	for i := range accounts {
+		var (
+			account    []byte
+			withdrawal TreasuryWithdrawal
+		)
+		if err := cbor.Unmarshal(accounts[i], &account); err != nil {
+			return err
+		}
+		if withdrawal.RewardAccount, err = rewardAccountFromBytes(account); err != nil {
+			return err
+		}
+		if err := cbor.Unmarshal(amounts[i], &withdrawal.Amount); err != nil {
+			return err
+		}
+		action.Withdrawals = append(action.Withdrawals, withdrawal)
+	}
+	if action.PolicyHash, err = unmarshalOptionalScriptHash(policyHash); err != nil {
+		return err
+	}
+
+	*a = action
+	return nil
+}
+
+// NoConfidenceAction proposes a motion of no confidence in the constitutional committee.
+type NoConfidenceAction struct {
+	PrevActionId *GovActionId
+}
+
+// Type returns NoConfidenceGovActionType.
+func (a *NoConfidenceAction) Type() GovActionType {
+	return NoConfidenceGovActionType
+}
+
+// MarshalCBOR returns the cbor encoding of the governance action.
+func (a *NoConfidenceAction) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{a.Type(), optionalGovActionId(a.PrevActionId)})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded no confidence action.
+func (a *NoConfidenceAction) UnmarshalCBOR(data []byte) error {
+	action := NoConfidenceAction{}
+	if err := unmarshalTypedArray(data, uint(NoConfidenceGovActionType), ErrInvalidProposal, &action.PrevActionId); err != nil {
+		return err
+	}
+	*a = action
+	return nil
+}
+
+// CommitteeMember is a constitutional committee cold credential and the epoch its term ends.
+type CommitteeMember struct {
+	ColdCredential address.StakeCredential
+	Epoch          uint64
+}
+
+// UpdateCommitteeAction proposes to remove and add constitutional committee members and to change the quorum.
+type UpdateCommitteeAction struct {
+	PrevActionId *GovActionId
+	Remove       []address.StakeCredential
+	Add          []CommitteeMember
+	Threshold    UnitInterval
+}
+
+// Type returns UpdateCommitteeGovActionType.
+func (a *UpdateCommitteeAction) Type() GovActionType {
+	return UpdateCommitteeGovActionType
+}
+
+// MarshalCBOR returns the cbor encoding of the governance action.
+func (a *UpdateCommitteeAction) MarshalCBOR() ([]byte, error) {
+	if err := a.Threshold.Validate(); err != nil {
+		return nil, err
+	}
+
+	remove := a.Remove
+	if remove == nil {
+		remove = []address.StakeCredential{}
+	}
+
+	var creds, epochs [][]byte
+	for _, member := range a.Add {
+		cred, err := member.ColdCredential.MarshalCBOR()
+		if err != nil {
+			return nil, err
+		}
+		epoch, err := cbor.Marshal(member.Epoch)
+		if err != nil {
+			return nil, err
+		}
+		creds = append(creds, cred)
+		epochs = append(epochs, epoch)
+	}
+
+	return cbor.Marshal([]interface{}{
+		a.Type(),
+		optionalGovActionId(a.PrevActionId),
+		remove,
+		cbor.RawMessage(marshalMap(creds, epochs)),
+		a.Threshold,
+	})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded update committee action.
+func (a *UpdateCommitteeAction) UnmarshalCBOR(data []byte) error {
+	var add cbor.RawMessage
+	action := UpdateCommitteeAction{}
+	err := unmarshalTypedArray(data, uint(UpdateCommitteeGovActionType), ErrInvalidProposal, &action.PrevActionId, &action.Remove, &add, &action.Threshold)
+	if err != nil {
+		return err
+	}
+	creds, epochs, err := unmarshalMap(add)
+	if err != nil {
+		return err
+	}
+
+	for i := range creds {
+		var member CommitteeMember
+		if err := cbor.Unmarshal(creds[i], &member.ColdCredential); err != nil {
+			return err
+		}
+		if err := cbor.Unmarshal(epochs[i], &member.Epoch); err != nil {
+			return err
+		}
+		action.Add = append(action.Add, member)
+	}
+
+	*a = action
+	return nil
+}
+
+// Constitution anchors the text of the constitution and optionally names the guardrails script.
+type Constitution struct {
+	Anchor     Anchor
+	ScriptHash *crypto.ScriptHash
+}
+
+// MarshalCBOR returns the cbor encoding of the constitution.
+func (c Constitution) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{&c.Anchor, optionalScriptHash(c.ScriptHash)})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded constitution.
+func (c *Constitution) UnmarshalCBOR(data []byte) error {
+	var scriptHash cbor.RawMessage
+	constitution := Constitution{}
+	err := unmarshalArray(data, ErrInvalidProposal, &constitution.Anchor, &scriptHash)
+	if err != nil {
+		return err
+	}
+	if constitution.ScriptHash, err = unmarshalOptionalScriptHash(scriptHash); err != nil {
+		return err
+	}
+	*c = constitution
+	return nil
+}
+
+// NewConstitutionAction proposes to change the constitution.
+type NewConstitutionAction struct {
+	PrevActionId *GovActionId
+	Constitution Constitution
+}
+
+// Type returns NewConstitutionGovActionType.
+func (a *NewConstitutionAction) Type() GovActionType {
+	return NewConstitutionGovActionType
+}
+
+// MarshalCBOR returns the cbor encoding of the governance action.
+func (a *NewConstitutionAction) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{a.Type(), optionalGovActionId(a.PrevActionId), a.Constitution})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded new constitution action.
+func (a *NewConstitutionAction) UnmarshalCBOR(data []byte) error {
+	action := NewConstitutionAction{}
+	if err := unmarshalTypedArray(data, uint(NewConstitutionGovActionType), ErrInvalidProposal, &action.PrevActionId, &action.Constitution); err != nil {
+		return err
+	}
+	*a = action
+	return nil
+}
+
+// InfoAction is a governance action without effect on chain, used to poll the opinion of voters.
+type InfoAction struct{}
+
+// Type returns InfoGovActionType.
+func (a *InfoAction) Type() GovActionType {
+	return InfoGovActionType
+}
+
+// MarshalCBOR returns the cbor encoding of the governance action.
+func (a *InfoAction) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{a.Type()})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded info action.
+func (a *InfoAction) UnmarshalCBOR(data []byte) error {
+	return unmarshalTypedArray(data, uint(InfoGovActionType), ErrInvalidProposal)
+}
+
+// ProposalProcedure submits a governance action. The deposit is returned to the reward account
+// once the action is enacted or expires.
+type ProposalProcedure struct {
+	Deposit       uint64
+	RewardAccount *address.RewardAddress
+	Action        GovAction
+	Anchor        Anchor
+}
+
+// NewProposalProcedure returns a pointer to a new ProposalProcedure. Proposals require a reward account,
+// an action and an anchor.
+func NewProposalProcedure(deposit uint64, rewardAccount *address.RewardAddress, action GovAction, anchor *Anchor) (*ProposalProcedure, error) {
+	if rewardAccount == nil || action == nil || anchor == nil {
+		return nil, ErrInvalidProposal
+	}
+	return &ProposalProcedure{
+		Deposit:       deposit,
+		RewardAccount: rewardAccount,
+		Action:        action,
+		Anchor:        *anchor,
+	}, nil
+}
+
+// MarshalCBOR returns the cbor encoding of the proposal procedure.
+func (p *ProposalProcedure) MarshalCBOR() ([]byte, error) {
+	if p.RewardAccount == nil || p.Action == nil {
+		return nil, ErrInvalidProposal
+	}
+	return cbor.Marshal([]interface{}{p.Deposit, p.RewardAccount.Bytes(), p.Action, &p.Anchor})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded proposal procedure.
+func (p *ProposalProcedure) UnmarshalCBOR(data []byte) error {
+	var (
+		rewardAccount []byte
+		action        cbor.RawMessage
+	)
+	proposal := ProposalProcedure{}
+	err := unmarshalArray(data, ErrInvalidProposal, &proposal.Deposit, &rewardAccount, &action, &proposal.Anchor)
+	if err != nil {
+		return err
+	}
+	if proposal.RewardAccount, err = rewardAccountFromBytes(rewardAccount); err != nil {
+		return err
+	}
+	if proposal.Action, err = unmarshalGovAction(action); err != nil {
+		return err
+	}
+	*p = proposal
+	return nil
+}
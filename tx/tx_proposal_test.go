@@ -0,0 +1,210 @@
+package tx_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fivebinaries/go-cardano-serialization/network"
+	"github.com/fivebinaries/go-cardano-serialization/protocol"
+	"github.com/fivebinaries/go-cardano-serialization/tx"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGovActionEncoding(t *testing.T) {
+	hash := bytes.Repeat([]byte{0x02}, 28)
+	hashHex := hex.EncodeToString(hash)
+	txHash, _ := crypto.TransactionHashFromBytes(bytes.Repeat([]byte{0x04}, 32))
+	txHashHex := hex.EncodeToString(txHash[:])
+	dataHash, _ := crypto.AnchorDataHashFromBytes(bytes.Repeat([]byte{0x03}, 32))
+	anchor, _ := tx.NewAnchor("https://a.b", dataHash)
+	anchorHex := "826b" + hex.EncodeToString([]byte("https://a.b")) + "5820" + hex.EncodeToString(dataHash[:])
+
+	minFeeA := uint64(44)
+	threshold, _ := tx.NewUnitInterval(2, 3)
+	reward := address.NewRewardAddress(network.TestNet(), address.NewKeyStakeCredential(hash))
+
+	scenarios := []struct {
+		description string
+		action      tx.GovAction
+		cborHex     string
+	}{
+		{
+			description: "parameter change",
+			action:      &tx.ParameterChangeAction{Update: tx.ProtocolParamUpdate{MinFeeA: &minFeeA}},
+			cborHex:     "8400f6a100182cf6",
+		},
+		{
+			description: "parameter change of cost models",
+			action: &tx.ParameterChangeAction{Update: tx.ProtocolParamUpdate{
+				CostModels: map[uint][]int64{tx.PlutusV3: {1, 2}, tx.PlutusV1: {3}, tx.PlutusV2: {4}},
+			}},
+			cborHex: "8400f6a112a3008103018104028201" + "02f6",
+		},
+		{
+			description: "hard fork initiation",
+			action: &tx.HardForkInitiationAction{
+				PrevActionId:    &tx.GovActionId{TxHash: txHash, Index: 1},
+				ProtocolVersion: protocol.ProtocolVersion{Major: 10},
+			},
+			cborHex: "8301825820" + txHashHex + "01820a00",
+		},
+		{
+			description: "treasury withdrawals",
+			action: &tx.TreasuryWithdrawalsAction{
+				Withdrawals: []tx.TreasuryWithdrawal{{RewardAccount: reward, Amount: 1000000}},
+			},
+			cborHex: "8302a1581de0" + hashHex + "1a000f4240f6",
+		},
+		{
+			description: "no confidence",
+			action:      &tx.NoConfidenceAction{},
+			cborHex:     "8203f6",
+		},
+		{
+			description: "update committee",
+			action: &tx.UpdateCommitteeAction{
+				Remove:    []address.StakeCredential{*address.NewKeyStakeCredential(hash)},
+				Add:       []tx.CommitteeMember{{ColdCredential: *address.NewScriptStakeCredential(hash), Epoch: 500}},
+				Threshold: *threshold,
+			},
+			cborHex: "8504f6818200581c" + hashHex + "a18201581c" + hashHex + "1901f4d81e820203",
+		},
+		{
+			description: "new constitution",
+			action:      &tx.NewConstitutionAction{Constitution: tx.Constitution{Anchor: *anchor}},
+			cborHex:     "8305f682" + anchorHex + "f6",
+		},
+		{
+			description: "info",
+			action:      &tx.InfoAction{},
+			cborHex:     "8106",
+		},
+	}
+
+	for _, sc := range scenarios {
+		t.Run(sc.description, func(t *testing.T) {
+			data, err := sc.action.MarshalCBOR()
+			assert.NoError(t, err)
+			assert.Equal(t, sc.cborHex, hex.EncodeToString(data))
+
+			proposal, err := tx.NewProposalProcedure(100000000000, reward, sc.action, anchor)
+			assert.NoError(t, err)
+			data, err = proposal.MarshalCBOR()
+			assert.NoError(t, err)
+			decoded := &tx.ProposalProcedure{}
+			assert.NoError(t, cbor.Unmarshal(data, decoded))
+			assert.Equal(t, proposal, decoded)
+		})
+	}
+
+	proposal, err := tx.NewProposalProcedure(100000000000, reward, &tx.InfoAction{}, anchor)
+	assert.NoError(t, err)
+	data, err := proposal.MarshalCBOR()
+	assert.NoError(t, err)
+	assert.Equal(t, "841b000000174876e800581de0"+hashHex+"8106"+anchorHex, hex.EncodeToString(data))
+
+	_, err = tx.NewProposalProcedure(100000000000, reward, &tx.InfoAction{}, nil)
+	assert.ErrorIs(t, err, tx.ErrInvalidProposal)
+	_, err = tx.NewProposalProcedure(100000000000, nil, &tx.InfoAction{}, anchor)
+	assert.ErrorIs(t, err, tx.ErrInvalidProposal)
+
+	voter, err := tx.NewDRepVoter(address.NewKeyStakeCredential(hash))
+	assert.NoError(t, err)
+	procedures := tx.VotingProcedures{
+		*voter: {tx.GovActionId{TxHash: txHash}: {Vote: tx.VoteYes}},
+	}
+	data, err = procedures.MarshalCBOR()
+	assert.NoError(t, err)
+	assert.Equal(t, "a18202581c"+hashHex+"a1825820"+txHashHex+"008201f6", hex.EncodeToString(data))
+	decodedProcedures := tx.VotingProcedures{}
+	assert.NoError(t, cbor.Unmarshal(data, &decodedProcedures))
+	assert.Equal(t, procedures, decodedProcedures)
+
+	actionId := tx.GovActionId{TxHash: txHash, Index: 1}
+	assert.Equal(t, "gov_action1qszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqz85e3pm", actionId.String())
+	decoded, err := tx.NewGovActionIdFromBech32(actionId.String())
+	assert.NoError(t, err)
+	assert.Equal(t, actionId, *decoded)
+
+	_, err = tx.NewDRepVoter(address.NewKeyStakeCredential(hash[:27]))
+	assert.ErrorIs(t, err, tx.ErrInvalidVoter)
+}
+
+func TestCostModelsEncoding(t *testing.T) {
+	update := tx.ProtocolParamUpdate{CostModels: map[uint][]int64{
+		tx.PlutusV1: {205665, 812, 1},
+		tx.PlutusV2: {205665, 812, 1, 1000},
+		tx.PlutusV3: {100788, 420, 1, 1, 1000},
+	}}
+	expected, err := cbor.Marshal(update)
+	assert.NoError(t, err)
+	// Hashing and serializing a transaction encode its body separately, both must give the same bytes.
+	for i := 0; i < 100; i++ {
+		data, err := cbor.Marshal(update)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, data)
+	}
+
+	var decoded tx.ProtocolParamUpdate
+	assert.NoError(t, cbor.Unmarshal(expected, &decoded))
+	assert.Equal(t, update, decoded)
+}
+
+func TestTxBuilderGovernanceProcedures(t *testing.T) {
+	rootKey := createRootKey()
+	addr, utxoPrv, err := generateBaseAddress(network.TestNet())
+	if err != nil {
+		t.Fatal(err)
+	}
+	accountKey := rootKey.Derive(harden(1852)).Derive(harden(1815)).Derive(harden(0))
+	drepPrv := accountKey.Derive(3).Derive(0)
+	drepHash := drepPrv.Public().PublicKey().Hash()
+	voter, _ := tx.NewDRepVoter(address.NewKeyStakeCredential(drepHash[:]))
+	reward := address.NewRewardAddress(network.TestNet(), &addr.Stake)
+	dataHash, _ := crypto.AnchorDataHashFromBytes(bytes.Repeat([]byte{0x03}, 32))
+	anchor, _ := tx.NewAnchor("https://a.b", dataHash)
+
+	pr := protocol.Protocol{TxFeePerByte: 44, TxFeeFixed: 155381, GovActionDeposit: 100000000}
+	newBuilder := func(deposit uint64, xprvs ...bip32.XPrv) *tx.TxBuilder {
+		builder := tx.NewTxBuilder(pr, xprvs)
+		builder.AddInputs(tx.NewTxInput("fcbc18c64cdf133f33dd319c5105dc7c4972f2d646ae276fbd00cf7f39f8c380", 0, 1000000000))
+		proposal, err := tx.NewProposalProcedure(deposit, reward, &tx.InfoAction{}, anchor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		builder.AddProposals(proposal)
+		builder.AddVote(voter, tx.GovActionId{}, tx.VotingProcedure{Vote: tx.VoteAbstain})
+		builder.SetDonation(5000000)
+		builder.SetCurrentTreasuryValue(1000000000000)
+		builder.AddChangeIfNeeded(addr)
+		return builder
+	}
+
+	builder := newBuilder(100000000, utxoPrv, drepPrv)
+	txBody := builder.Tx().Body
+	assert.Equal(t, uint(1000000000-105000000)-uint(txBody.Fee), txBody.Outputs[0].Amount)
+	txFinal, err := builder.Build()
+	assert.NoError(t, err)
+	assert.Len(t, txFinal.Witness.Keys, 2)
+
+	data, err := txFinal.Bytes()
+	assert.NoError(t, err)
+	decoded := &tx.Tx{}
+	assert.NoError(t, cbor.Unmarshal(data, decoded))
+	assert.Equal(t, txBody.ProposalProcedures, decoded.Body.ProposalProcedures)
+	assert.Equal(t, txBody.VotingProcedures, decoded.Body.VotingProcedures)
+	invalid, err := decoded.VerifyWitnesses()
+	assert.NoError(t, err)
+	assert.Empty(t, invalid)
+
+	assert.Equal(t, []crypto.Ed25519KeyHash{drepHash}, newBuilder(100000000, utxoPrv).MissingSigners())
+	assert.Empty(t, builder.MissingSigners())
+
+	_, err = newBuilder(1000000, utxoPrv, drepPrv).Build()
+	assert.ErrorIs(t, err, tx.ErrInvalidDeposit)
+}
@@ -0,0 +1,172 @@
+package tx
+
+import (
+	"errors"
+
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fxamacker/cbor/v2"
+)
+
+var (
+	ErrInvalidNativeScript = errors.New("invalid native script")
+	ErrInvalidPlutusScript = errors.New("invalid plutus script")
+)
+
+// NativeScriptType is the tag identifying the kind of a native script in its cbor encoding.
+type NativeScriptType uint
+
+const (
+	PubKeyNativeScriptType NativeScriptType = iota
+	AllNativeScriptType
+	AnyNativeScriptType
+	NOfKNativeScriptType
+	InvalidBeforeNativeScriptType
+	InvalidHereafterNativeScriptType
+)
+
+// Script hash prefixes of the script languages.
+const (
+	nativeScriptHashPrefix byte = iota
+	plutusV1ScriptHashPrefix
+	plutusV2ScriptHashPrefix
+	plutusV3ScriptHashPrefix
+)
+
+// NativeScript is a multi-signature and timelock script. Only the fields used by Type are encoded.
+type NativeScript struct {
+	Type    NativeScriptType
+	KeyHash crypto.Ed25519KeyHash
+	Scripts []*NativeScript
+	N       uint64
+	Slot    uint64
+}
+
+// NewPubKeyNativeScript returns a pointer to a NativeScript requiring a signature of the key.
+func NewPubKeyNativeScript(keyHash crypto.Ed25519KeyHash) *NativeScript {
+	return &NativeScript{Type: PubKeyNativeScriptType, KeyHash: keyHash}
+}
+
+// NewAllNativeScript returns a pointer to a NativeScript requiring all of the scripts.
+func NewAllNativeScript(scripts ...*NativeScript) *NativeScript {
+	return &NativeScript{Type: AllNativeScriptType, Scripts: scripts}
+}
+
+// NewAnyNativeScript returns a pointer to a NativeScript requiring any of the scripts.
+func NewAnyNativeScript(scripts ...*NativeScript) *NativeScript {
+	return &NativeScript{Type: AnyNativeScriptType, Scripts: scripts}
+}
+
+// NewNOfKNativeScript returns a pointer to a NativeScript requiring n of the scripts.
+func NewNOfKNativeScript(n uint64, scripts ...*NativeScript) *NativeScript {
+	return &NativeScript{Type: NOfKNativeScriptType, N: n, Scripts: scripts}
+}
+
+// NewInvalidBeforeNativeScript returns a pointer to a NativeScript valid from the slot.
+func NewInvalidBeforeNativeScript(slot uint64) *NativeScript {
+	return &NativeScript{Type: InvalidBeforeNativeScriptType, Slot: slot}
+}
+
+// NewInvalidHereafterNativeScript returns a pointer to a NativeScript valid before the slot.
+func NewInvalidHereafterNativeScript(slot uint64) *NativeScript {
+	return &NativeScript{Type: InvalidHereafterNativeScriptType, Slot: slot}
+}
+
+// MarshalCBOR returns the cbor encoding of the native script.
+func (s *NativeScript) MarshalCBOR() ([]byte, error) {
+	scripts := s.Scripts
+	if scripts == nil {
+		scripts = []*NativeScript{}
+	}
+
+	switch s.Type {
+	case PubKeyNativeScriptType:
+		return cbor.Marshal([]interface{}{s.Type, s.KeyHash[:]})
+	case AllNativeScriptType, AnyNativeScriptType:
+		return cbor.Marshal([]interface{}{s.Type, scripts})
+	case NOfKNativeScriptType:
+		return cbor.Marshal([]interface{}{s.Type, s.N, scripts})
+	case InvalidBeforeNativeScriptType, InvalidHereafterNativeScriptType:
+		return cbor.Marshal([]interface{}{s.Type, s.Slot})
+	}
+	return nil, ErrInvalidNativeScript
+}
+
+// UnmarshalCBOR deserializes a cbor encoded native script.
+func (s *NativeScript) UnmarshalCBOR(data []byte) error {
+	var raw []cbor.RawMessage
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) < 2 {
+		return ErrInvalidNativeScript
+	}
+
+	script := NativeScript{}
+	if err := cbor.Unmarshal(raw[0], &script.Type); err != nil {
+		return err
+	}
+
+	var err error
+	switch {
+	case script.Type == PubKeyNativeScriptType && len(raw) == 2:
+		var keyHash []byte
+		if err = cbor.Unmarshal(raw[1], &keyHash); err == nil {
+			script.KeyHash, err = crypto.Ed25519KeyHashFromBytes(keyHash)
+		}
+	case (script.Type == AllNativeScriptType || script.Type == AnyNativeScriptType) && len(raw) == 2:
+		err = cbor.Unmarshal(raw[1], &script.Scripts)
+	case script.Type == NOfKNativeScriptType && len(raw) == 3:
+		if err = cbor.Unmarshal(raw[1], &script.N); err == nil {
+			err = cbor.Unmarshal(raw[2], &script.Scripts)
+		}
+	case (script.Type == InvalidBeforeNativeScriptType || script.Type == InvalidHereafterNativeScriptType) && len(raw) == 2:
+		err = cbor.Unmarshal(raw[1], &script.Slot)
+	default:
+		return ErrInvalidNativeScript
+	}
+	if err != nil {
+		return err
+	}
+
+	*s = script
+	return nil
+}
+
+// Hash returns the script hash of the native script, used as policy id and in script credentials.
+func (s *NativeScript) Hash() (crypto.ScriptHash, error) {
+	data, err := s.MarshalCBOR()
+	if err != nil {
+		return crypto.ScriptHash{}, err
+	}
+	return crypto.Blake2b224(append([]byte{nativeScriptHashPrefix}, data...)), nil
+}
+
+// PlutusScript is a compiled Plutus script of a language version, PlutusV1, PlutusV2 or PlutusV3.
+type PlutusScript struct {
+	Version uint
+	Script  []byte
+}
+
+// NewPlutusScript returns a pointer to a new PlutusScript of the language version.
+func NewPlutusScript(version uint, script []byte) (*PlutusScript, error) {
+	if version > PlutusV3 {
+		return nil, ErrInvalidPlutusScript
+	}
+	return &PlutusScript{Version: version, Script: script}, nil
+}
+
+// Hash returns the script hash of the Plutus script.
+func (s *PlutusScript) Hash() (crypto.ScriptHash, error) {
+	var prefix byte
+	switch s.Version {
+	case PlutusV1:
+		prefix = plutusV1ScriptHashPrefix
+	case PlutusV2:
+		prefix = plutusV2ScriptHashPrefix
+	case PlutusV3:
+		prefix = plutusV3ScriptHashPrefix
+	default:
+		return crypto.ScriptHash{}, ErrInvalidPlutusScript
+	}
+	return crypto.Blake2b224(append([]byte{prefix}, s.Script...)), nil
+}
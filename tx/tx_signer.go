@@ -0,0 +1,72 @@
+package tx
+
+import (
+	"crypto/ed25519"
+	"errors"
+
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+)
+
+var ErrVerificationOnly = errors.New("verification-only signer cannot sign")
+
+// Signer creates the vkey witnesses of a transaction.
+type Signer interface {
+	// PublicKey returns the verification key of the witnesses.
+	PublicKey() bip32.PublicKey
+	// Sign returns the signature of the transaction body hash, or ErrVerificationOnly if the signer
+	// has no private key.
+	Sign(txHash []byte) ([]byte, error)
+}
+
+type extendedKeySigner struct {
+	key bip32.XPrv
+}
+
+// NewExtendedKeySigner returns a Signer of the extended private key, e.g. a key derived from a wallet.
+func NewExtendedKeySigner(key bip32.XPrv) Signer {
+	return extendedKeySigner{key: key}
+}
+
+func (s extendedKeySigner) PublicKey() bip32.PublicKey {
+	return s.key.Public().PublicKey()
+}
+
+func (s extendedKeySigner) Sign(txHash []byte) ([]byte, error) {
+	signature := s.key.Sign(txHash)
+	return signature[:], nil
+}
+
+type ed25519Signer struct {
+	key ed25519.PrivateKey
+}
+
+// NewEd25519Signer returns a Signer of the Ed25519 private key, e.g. a key generated by `cardano-cli address key-gen`.
+func NewEd25519Signer(key ed25519.PrivateKey) Signer {
+	return ed25519Signer{key: key}
+}
+
+func (s ed25519Signer) PublicKey() bip32.PublicKey {
+	return bip32.PublicKey(s.key.Public().(ed25519.PublicKey))
+}
+
+func (s ed25519Signer) Sign(txHash []byte) ([]byte, error) {
+	return ed25519.Sign(s.key, txHash), nil
+}
+
+type verificationKeySigner struct {
+	key bip32.PublicKey
+}
+
+// NewVerificationKeySigner returns a Signer of the public key which cannot sign. It stands in for keys
+// held elsewhere, e.g. on a hardware wallet, so the transaction fee accounts for their witnesses.
+func NewVerificationKeySigner(key bip32.PublicKey) Signer {
+	return verificationKeySigner{key: key}
+}
+
+func (s verificationKeySigner) PublicKey() bip32.PublicKey {
+	return s.key
+}
+
+func (s verificationKeySigner) Sign(txHash []byte) ([]byte, error) {
+	return nil, ErrVerificationOnly
+}
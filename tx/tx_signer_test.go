@@ -0,0 +1,77 @@
+package tx_test
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/fivebinaries/go-cardano-serialization/network"
+	"github.com/fivebinaries/go-cardano-serialization/protocol"
+	"github.com/fivebinaries/go-cardano-serialization/tx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigners(t *testing.T) {
+	addr, utxoPrv, err := generateBaseAddress(network.TestNet())
+	if err != nil {
+		t.Fatal(err)
+	}
+	seed := make([]byte, ed25519.SeedSize)
+	seed[0] = 1
+	cliKey := ed25519.NewKeyFromSeed(seed)
+	hardwareKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+
+	newBuilder := func(signers ...tx.Signer) *tx.TxBuilder {
+		builder := tx.NewTxBuilder(protocol.Protocol{TxFeePerByte: 44, TxFeeFixed: 155381}, []bip32.XPrv{utxoPrv})
+		builder.AddSigners(signers...)
+		builder.AddInputs(tx.NewTxInput("fcbc18c64cdf133f33dd319c5105dc7c4972f2d646ae276fbd00cf7f39f8c380", 0, 1000000000))
+		builder.AddChangeIfNeeded(addr)
+		return builder
+	}
+
+	cliSigner := tx.NewEd25519Signer(cliKey)
+	assert.Equal(t, bip32.PublicKey(cliKey.Public().(ed25519.PublicKey)), cliSigner.PublicKey())
+
+	builder := newBuilder(cliSigner)
+	txFinal, err := builder.Build()
+	assert.NoError(t, err)
+	assert.Len(t, txFinal.Witness.Keys, 2)
+	assert.Equal(t, cliSigner.PublicKey(), bip32.PublicKey(txFinal.Witness.Keys[1].VKey))
+	invalid, err := txFinal.VerifyWitnesses()
+	assert.NoError(t, err)
+	assert.Empty(t, invalid)
+
+	// A verification-only signer adds to the fee but leaves its witness to the caller.
+	hardwareSigner := tx.NewVerificationKeySigner(bip32.PublicKey(hardwareKey.Public().(ed25519.PublicKey)))
+	_, err = hardwareSigner.Sign([]byte("tx hash"))
+	assert.Equal(t, tx.ErrVerificationOnly, err)
+
+	// Fees are compared on builders without change, MinFee must not leave dummy witnesses behind.
+	newUnbalanced := func(signers ...tx.Signer) *tx.TxBuilder {
+		builder := tx.NewTxBuilder(protocol.Protocol{TxFeePerByte: 44, TxFeeFixed: 155381}, []bip32.XPrv{utxoPrv})
+		builder.AddSigners(signers...)
+		builder.AddInputs(tx.NewTxInput("fcbc18c64cdf133f33dd319c5105dc7c4972f2d646ae276fbd00cf7f39f8c380", 0, 1000000000))
+		return builder
+	}
+	cliOnly := newUnbalanced(cliSigner)
+	fee := cliOnly.MinFee()
+	assert.Empty(t, cliOnly.Tx().Witness.Keys)
+	assert.Equal(t, fee, cliOnly.MinFee())
+	assert.Greater(t, newUnbalanced(cliSigner, hardwareSigner).MinFee(), fee)
+
+	withHardware := newBuilder(cliSigner, hardwareSigner)
+	assert.Empty(t, withHardware.Tx().Witness.Keys)
+	assert.Greater(t, uint64(withHardware.MinFee()), builder.Tx().Body.Fee)
+	txFinal, err = withHardware.Build()
+	assert.NoError(t, err)
+	assert.Len(t, txFinal.Witness.Keys, 2)
+
+	txHash, err := txFinal.Hash()
+	assert.NoError(t, err)
+	signature, err := tx.NewEd25519Signer(hardwareKey).Sign(txHash[:])
+	assert.NoError(t, err)
+	txFinal.Witness.Keys = append(txFinal.Witness.Keys, tx.NewVKeyWitness(hardwareSigner.PublicKey(), signature))
+	invalid, err = txFinal.VerifyWitnesses()
+	assert.NoError(t, err)
+	assert.Empty(t, invalid)
+}
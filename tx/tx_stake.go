@@ -0,0 +1,297 @@
+package tx
+
+import (
+	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// StakeRegistration is a certificate registering a stake credential. Without a deposit it is encoded as
+// the shelley certificate paying the key deposit of the protocol parameters, with a deposit as the conway
+// registration certificate stating the deposit explicitly.
+type StakeRegistration struct {
+	Stake   address.StakeCredential
+	Deposit *uint64
+}
+
+// NewStakeRegistration returns a pointer to a new shelley StakeRegistration certificate.
+func NewStakeRegistration(stake *address.StakeCredential) *StakeRegistration {
+	return &StakeRegistration{Stake: *stake}
+}
+
+// NewStakeRegistrationWithDeposit returns a pointer to a new conway StakeRegistration certificate.
+func NewStakeRegistrationWithDeposit(stake *address.StakeCredential, deposit uint64) *StakeRegistration {
+	return &StakeRegistration{Stake: *stake, Deposit: &deposit}
+}
+
+// Type returns StakeRegistrationCertificateType or RegistrationCertificateType if the deposit is set.
+func (s *StakeRegistration) Type() CertificateType {
+	if s.Deposit != nil {
+		return RegistrationCertificateType
+	}
+	return StakeRegistrationCertificateType
+}
+
+// MarshalCBOR returns the cbor encoding of the stake registration certificate.
+func (s *StakeRegistration) MarshalCBOR() ([]byte, error) {
+	if s.Deposit != nil {
+		return cbor.Marshal([]interface{}{s.Type(), s.Stake, *s.Deposit})
+	}
+	return cbor.Marshal([]interface{}{s.Type(), s.Stake})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded shelley or conway stake registration certificate.
+func (s *StakeRegistration) UnmarshalCBOR(data []byte) error {
+	cert := StakeRegistration{}
+	err := unmarshalTypedArray(data, uint(StakeRegistrationCertificateType), ErrInvalidCertificate, &cert.Stake)
+	if err == ErrInvalidCertificate {
+		cert.Deposit = new(uint64)
+		err = unmarshalTypedArray(data, uint(RegistrationCertificateType), ErrInvalidCertificate, &cert.Stake, cert.Deposit)
+	}
+	if err != nil {
+		return err
+	}
+	*s = cert
+	return nil
+}
+
+// StakeDeregistration is a certificate deregistering a stake credential and refunding its deposit.
+// Without a refund it is encoded as the shelley certificate refunding the key deposit of the protocol
+// parameters, with a refund as the conway unregistration certificate.
+type StakeDeregistration struct {
+	Stake  address.StakeCredential
+	Refund *uint64
+}
+
+// NewStakeDeregistration returns a pointer to a new shelley StakeDeregistration certificate.
+func NewStakeDeregistration(stake *address.StakeCredential) *StakeDeregistration {
+	return &StakeDeregistration{Stake: *stake}
+}
+
+// NewStakeDeregistrationWithRefund returns a pointer to a new conway StakeDeregistration certificate.
+func NewStakeDeregistrationWithRefund(stake *address.StakeCredential, refund uint64) *StakeDeregistration {
+	return &StakeDeregistration{Stake: *stake, Refund: &refund}
+}
+
+// Type returns StakeDeregistrationCertificateType or UnregistrationCertificateType if the refund is set.
+func (s *StakeDeregistration) Type() CertificateType {
+	if s.Refund != nil {
+		return UnregistrationCertificateType
+	}
+	return StakeDeregistrationCertificateType
+}
+
+// MarshalCBOR returns the cbor encoding of the stake deregistration certificate.
+func (s *StakeDeregistration) MarshalCBOR() ([]byte, error) {
+	if s.Refund != nil {
+		return cbor.Marshal([]interface{}{s.Type(), s.Stake, *s.Refund})
+	}
+	return cbor.Marshal([]interface{}{s.Type(), s.Stake})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded shelley or conway stake deregistration certificate.
+func (s *StakeDeregistration) UnmarshalCBOR(data []byte) error {
+	cert := StakeDeregistration{}
+	err := unmarshalTypedArray(data, uint(StakeDeregistrationCertificateType), ErrInvalidCertificate, &cert.Stake)
+	if err == ErrInvalidCertificate {
+		cert.Refund = new(uint64)
+		err = unmarshalTypedArray(data, uint(UnregistrationCertificateType), ErrInvalidCertificate, &cert.Stake, cert.Refund)
+	}
+	if err != nil {
+		return err
+	}
+	*s = cert
+	return nil
+}
+
+// StakeDelegation is a certificate delegating the stake of a credential to a stake pool.
+type StakeDelegation struct {
+	Stake address.StakeCredential
+	Pool  crypto.Ed25519KeyHash
+}
+
+// NewStakeDelegation returns a pointer to a new StakeDelegation certificate.
+func NewStakeDelegation(stake *address.StakeCredential, pool crypto.Ed25519KeyHash) *StakeDelegation {
+	return &StakeDelegation{Stake: *stake, Pool: pool}
+}
+
+// Type returns StakeDelegationCertificateType.
+func (s *StakeDelegation) Type() CertificateType {
+	return StakeDelegationCertificateType
+}
+
+// MarshalCBOR returns the cbor encoding of the stake delegation certificate.
+func (s *StakeDelegation) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{s.Type(), s.Stake, s.Pool[:]})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded stake delegation certificate.
+func (s *StakeDelegation) UnmarshalCBOR(data []byte) error {
+	cert := StakeDelegation{}
+	if err := unmarshalTypedArray(data, uint(StakeDelegationCertificateType), ErrInvalidCertificate, &cert.Stake, fixed(cert.Pool[:])); err != nil {
+		return err
+	}
+	*s = cert
+	return nil
+}
+
+// VoteDelegation is a certificate delegating the voting power of a stake credential to a DRep.
+type VoteDelegation struct {
+	Stake address.StakeCredential
+	DRep  DRep
+}
+
+// NewVoteDelegation returns a pointer to a new VoteDelegation certificate.
+func NewVoteDelegation(stake *address.StakeCredential, drep *DRep) *VoteDelegation {
+	return &VoteDelegation{Stake: *stake, DRep: *drep}
+}
+
+// Type returns VoteDelegationCertificateType.
+func (v *VoteDelegation) Type() CertificateType {
+	return VoteDelegationCertificateType
+}
+
+// MarshalCBOR returns the cbor encoding of the vote delegation certificate.
+func (v *VoteDelegation) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{v.Type(), v.Stake, &v.DRep})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded vote delegation certificate.
+func (v *VoteDelegation) UnmarshalCBOR(data []byte) error {
+	cert := VoteDelegation{}
+	if err := unmarshalTypedArray(data, uint(VoteDelegationCertificateType), ErrInvalidCertificate, &cert.Stake, &cert.DRep); err != nil {
+		return err
+	}
+	*v = cert
+	return nil
+}
+
+// StakeVoteDelegation is a certificate delegating the stake of a credential to a stake pool and its voting power to a DRep.
+type StakeVoteDelegation struct {
+	Stake address.StakeCredential
+	Pool  crypto.Ed25519KeyHash
+	DRep  DRep
+}
+
+// NewStakeVoteDelegation returns a pointer to a new StakeVoteDelegation certificate.
+func NewStakeVoteDelegation(stake *address.StakeCredential, pool crypto.Ed25519KeyHash, drep *DRep) *StakeVoteDelegation {
+	return &StakeVoteDelegation{Stake: *stake, Pool: pool, DRep: *drep}
+}
+
+// Type returns StakeVoteDelegationCertificateType.
+func (s *StakeVoteDelegation) Type() CertificateType {
+	return StakeVoteDelegationCertificateType
+}
+
+// MarshalCBOR returns the cbor encoding of the stake and vote delegation certificate.
+func (s *StakeVoteDelegation) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{s.Type(), s.Stake, s.Pool[:], &s.DRep})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded stake and vote delegation certificate.
+func (s *StakeVoteDelegation) UnmarshalCBOR(data []byte) error {
+	cert := StakeVoteDelegation{}
+	if err := unmarshalTypedArray(data, uint(StakeVoteDelegationCertificateType), ErrInvalidCertificate, &cert.Stake, fixed(cert.Pool[:]), &cert.DRep); err != nil {
+		return err
+	}
+	*s = cert
+	return nil
+}
+
+// StakeRegistrationDelegation is a certificate registering a stake credential and delegating it to a stake pool.
+type StakeRegistrationDelegation struct {
+	Stake   address.StakeCredential
+	Pool    crypto.Ed25519KeyHash
+	Deposit uint64
+}
+
+// NewStakeRegistrationDelegation returns a pointer to a new StakeRegistrationDelegation certificate.
+func NewStakeRegistrationDelegation(stake *address.StakeCredential, pool crypto.Ed25519KeyHash, deposit uint64) *StakeRegistrationDelegation {
+	return &StakeRegistrationDelegation{Stake: *stake, Pool: pool, Deposit: deposit}
+}
+
+// Type returns StakeRegistrationDelegationCertificateType.
+func (s *StakeRegistrationDelegation) Type() CertificateType {
+	return StakeRegistrationDelegationCertificateType
+}
+
+// MarshalCBOR returns the cbor encoding of the stake registration and delegation certificate.
+func (s *StakeRegistrationDelegation) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{s.Type(), s.Stake, s.Pool[:], s.Deposit})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded stake registration and delegation certificate.
+func (s *StakeRegistrationDelegation) UnmarshalCBOR(data []byte) error {
+	cert := StakeRegistrationDelegation{}
+	if err := unmarshalTypedArray(data, uint(StakeRegistrationDelegationCertificateType), ErrInvalidCertificate, &cert.Stake, fixed(cert.Pool[:]), &cert.Deposit); err != nil {
+		return err
+	}
+	*s = cert
+	return nil
+}
+
+// VoteRegistrationDelegation is a certificate registering a stake credential and delegating its voting power to a DRep.
+type VoteRegistrationDelegation struct {
+	Stake   address.StakeCredential
+	DRep    DRep
+	Deposit uint64
+}
+
+// NewVoteRegistrationDelegation returns a pointer to a new VoteRegistrationDelegation certificate.
+func NewVoteRegistrationDelegation(stake *address.StakeCredential, drep *DRep, deposit uint64) *VoteRegistrationDelegation {
+	return &VoteRegistrationDelegation{Stake: *stake, DRep: *drep, Deposit: deposit}
+}
+
+// Type returns VoteRegistrationDelegationCertificateType.
+func (v *VoteRegistrationDelegation) Type() CertificateType {
+	return VoteRegistrationDelegationCertificateType
+}
+
+// MarshalCBOR returns the cbor encoding of the stake registration and vote delegation certificate.
+func (v *VoteRegistrationDelegation) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{v.Type(), v.Stake, &v.DRep, v.Deposit})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded stake registration and vote delegation certificate.
+func (v *VoteRegistrationDelegation) UnmarshalCBOR(data []byte) error {
+	cert := VoteRegistrationDelegation{}
+	if err := unmarshalTypedArray(data, uint(VoteRegistrationDelegationCertificateType), ErrInvalidCertificate, &cert.Stake, &cert.DRep, &cert.Deposit); err != nil {
+		return err
+	}
+	*v = cert
+	return nil
+}
+
+// StakeVoteRegistrationDelegation is a certificate registering a stake credential, delegating it to a stake pool
+// and delegating its voting power to a DRep.
+type StakeVoteRegistrationDelegation struct {
+	Stake   address.StakeCredential
+	Pool    crypto.Ed25519KeyHash
+	DRep    DRep
+	Deposit uint64
+}
+
+// NewStakeVoteRegistrationDelegation returns a pointer to a new StakeVoteRegistrationDelegation certificate.
+func NewStakeVoteRegistrationDelegation(stake *address.StakeCredential, pool crypto.Ed25519KeyHash, drep *DRep, deposit uint64) *StakeVoteRegistrationDelegation {
+	return &StakeVoteRegistrationDelegation{Stake: *stake, Pool: pool, DRep: *drep, Deposit: deposit}
+}
+
+// Type returns StakeVoteRegistrationDelegationCertificateType.
+func (s *StakeVoteRegistrationDelegation) Type() CertificateType {
+	return StakeVoteRegistrationDelegationCertificateType
+}
+
+// MarshalCBOR returns the cbor encoding of the stake registration, stake and vote delegation certificate.
+func (s *StakeVoteRegistrationDelegation) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{s.Type(), s.Stake, s.Pool[:], &s.DRep, s.Deposit})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded stake registration, stake and vote delegation certificate.
+func (s *StakeVoteRegistrationDelegation) UnmarshalCBOR(data []byte) error {
+	cert := StakeVoteRegistrationDelegation{}
+	if err := unmarshalTypedArray(data, uint(StakeVoteRegistrationDelegationCertificateType), ErrInvalidCertificate, &cert.Stake, fixed(cert.Pool[:]), &cert.DRep, &cert.Deposit); err != nil {
+		return err
+	}
+	*s = cert
+	return nil
+}
@@ -0,0 +1,240 @@
+package tx
+
+import (
+	"errors"
+
+	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fxamacker/cbor/v2"
+)
+
+var (
+	ErrInvalidVoter           = errors.New("invalid voter")
+	ErrInvalidGovActionId     = errors.New("invalid governance action id")
+	ErrInvalidVotingProcedure = errors.New("invalid voting procedure")
+)
+
+// VoterType is the tag identifying the kind of a voter in its cbor encoding.
+type VoterType uint
+
+const (
+	CommitteeHotKeyHashVoterType VoterType = iota
+	CommitteeHotScriptHashVoterType
+	DRepKeyHashVoterType
+	DRepScriptHashVoterType
+	StakePoolVoterType
+)
+
+// Voter is a constitutional committee member, delegate representative or stake pool casting votes.
+type Voter struct {
+	Kind VoterType
+	Hash [crypto.Ed25519KeyHashLen]byte
+}
+
+func newCredentialVoter(cred *address.StakeCredential, keyType, scriptType VoterType) (*Voter, error) {
+	if len(cred.Payload) != crypto.Ed25519KeyHashLen {
+		return nil, ErrInvalidVoter
+	}
+	voter := &Voter{Kind: keyType}
+	if cred.Kind == address.ScriptStakeCredentialType {
+		voter.Kind = scriptType
+	}
+	copy(voter.Hash[:], cred.Payload)
+	return voter, nil
+}
+
+// NewCommitteeVoter returns a pointer to a new Voter for the hot credential of a committee member.
+func NewCommitteeVoter(hot *address.StakeCredential) (*Voter, error) {
+	return newCredentialVoter(hot, CommitteeHotKeyHashVoterType, CommitteeHotScriptHashVoterType)
+}
+
+// NewDRepVoter returns a pointer to a new Voter for the credential of a delegate representative.
+func NewDRepVoter(cred *address.StakeCredential) (*Voter, error) {
+	return newCredentialVoter(cred, DRepKeyHashVoterType, DRepScriptHashVoterType)
+}
+
+// NewStakePoolVoter returns a pointer to a new Voter for a stake pool.
+func NewStakePoolVoter(pool crypto.Ed25519KeyHash) *Voter {
+	return &Voter{Kind: StakePoolVoterType, Hash: pool}
+}
+
+// KeyHash returns the key hash which has to witness the votes and false for script voters.
+func (v Voter) KeyHash() (crypto.Ed25519KeyHash, bool) {
+	switch v.Kind {
+	case CommitteeHotKeyHashVoterType, DRepKeyHashVoterType, StakePoolVoterType:
+		return v.Hash, true
+	}
+	return crypto.Ed25519KeyHash{}, false
+}
+
+// MarshalCBOR returns the cbor encoding of the voter.
+func (v Voter) MarshalCBOR() ([]byte, error) {
+	if v.Kind > StakePoolVoterType {
+		return nil, ErrInvalidVoter
+	}
+	return cbor.Marshal([]interface{}{v.Kind, v.Hash[:]})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded voter.
+func (v *Voter) UnmarshalCBOR(data []byte) error {
+	voter := Voter{}
+	if err := unmarshalArray(data, ErrInvalidVoter, &voter.Kind, fixed(voter.Hash[:])); err != nil {
+		return err
+	}
+	if voter.Kind > StakePoolVoterType {
+		return ErrInvalidVoter
+	}
+	*v = voter
+	return nil
+}
+
+// Vote is the decision of a voter on a governance action.
+type Vote uint
+
+const (
+	VoteNo Vote = iota
+	VoteYes
+	VoteAbstain
+)
+
+// GovActionId identifies a governance action by the transaction which proposed it and its index in the proposals.
+type GovActionId struct {
+	TxHash crypto.TransactionHash
+	Index  uint16
+}
+
+// MarshalCBOR returns the cbor encoding of the governance action id.
+func (g GovActionId) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{g.TxHash[:], g.Index})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded governance action id.
+func (g *GovActionId) UnmarshalCBOR(data []byte) error {
+	id := GovActionId{}
+	if err := unmarshalArray(data, ErrInvalidGovActionId, fixed(id.TxHash[:]), &id.Index); err != nil {
+		return err
+	}
+	*g = id
+	return nil
+}
+
+// NewGovActionIdFromBech32 returns a pointer to a new GovActionId decoded from a CIP-129 `gov_action` string.
+func NewGovActionIdFromBech32(raw string) (*GovActionId, error) {
+	txHash, index, err := address.DecodeGovActionId(raw)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := crypto.TransactionHashFromBytes(txHash)
+	if err != nil {
+		return nil, err
+	}
+	return &GovActionId{TxHash: hash, Index: index}, nil
+}
+
+// String returns the CIP-129 bech32 `gov_action` identifier.
+func (g GovActionId) String() string {
+	str, _ := address.EncodeGovActionId(g.TxHash[:], g.Index)
+	return str
+}
+
+// optionalGovActionId returns nil for a missing action id so it is encoded as cbor null.
+func optionalGovActionId(id *GovActionId) interface{} {
+	if id == nil {
+		return nil
+	}
+	return *id
+}
+
+// VotingProcedure is a vote optionally anchoring its rationale.
+type VotingProcedure struct {
+	Vote   Vote
+	Anchor *Anchor
+}
+
+// MarshalCBOR returns the cbor encoding of the voting procedure.
+func (v VotingProcedure) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]interface{}{v.Vote, optionalAnchor(v.Anchor)})
+}
+
+// UnmarshalCBOR deserializes a cbor encoded voting procedure.
+func (v *VotingProcedure) UnmarshalCBOR(data []byte) error {
+	procedure := VotingProcedure{}
+	if err := unmarshalArray(data, ErrInvalidVotingProcedure, &procedure.Vote, &procedure.Anchor); err != nil {
+		return err
+	}
+	if procedure.Vote > VoteAbstain {
+		return ErrInvalidVotingProcedure
+	}
+	*v = procedure
+	return nil
+}
+
+// VotingProcedures maps voters to their votes on governance actions.
+type VotingProcedures map[Voter]map[GovActionId]VotingProcedure
+
+// MarshalCBOR returns the cbor encoding of the voting procedures with voters and actions in canonical order.
+func (v VotingProcedures) MarshalCBOR() ([]byte, error) {
+	var voters, votes [][]byte
+	for voter, procedures := range v {
+		voterBytes, err := voter.MarshalCBOR()
+		if err != nil {
+			return nil, err
+		}
+
+		var ids, procs [][]byte
+		for id, procedure := range procedures {
+			idBytes, err := id.MarshalCBOR()
+			if err != nil {
+				return nil, err
+			}
+			procBytes, err := procedure.MarshalCBOR()
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, idBytes)
+			procs = append(procs, procBytes)
+		}
+
+		voters = append(voters, voterBytes)
+		votes = append(votes, marshalMap(ids, procs))
+	}
+	return marshalMap(voters, votes), nil
+}
+
+// UnmarshalCBOR deserializes cbor encoded voting procedures.
+func (v *VotingProcedures) UnmarshalCBOR(data []byte) error {
+	voters, votes, err := unmarshalMap(data)
+	if err != nil {
+		return err
+	}
+
+	procedures := VotingProcedures{}
+	for i := range voters {
+		var voter Voter
+		if err := cbor.Unmarshal(voters[i], &voter); err != nil {
+			return err
+		}
+		ids, procs, err := unmarshalMap(votes[i])
+		if err != nil {
+			return err
+		}
+
+		procedures[voter] = make(map[GovActionId]VotingProcedure, len(ids))
+		for j := range ids {
+			var (
+				id        GovActionId
+				procedure VotingProcedure
+			)
+			if err := cbor.Unmarshal(ids[j], &id); err != nil {
+				return err
+			}
+			if err := cbor.Unmarshal(procs[j], &procedure); err != nil {
+				return err
+			}
+			procedures[voter][id] = procedure
+		}
+	}
+
+	*v = procedures
+	return nil
+}
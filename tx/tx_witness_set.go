@@ -1,7 +1,15 @@
 package tx
 
+import (
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/fxamacker/cbor/v2"
+)
+
 type Witness struct {
 	Keys []*VKeyWitness `cbor:"0,keyasint,omitempty"`
+
+	// unknown are the decoded native scripts, bootstrap witnesses and plutus witnesses
+	unknown unknownEntries
 }
 
 // NewTXWitness returns a pointer to a Witness created from VKeyWitnesses.
@@ -17,6 +25,40 @@ func NewTXWitness(keys ...*VKeyWitness) *Witness {
 	}
 }
 
+// MarshalCBOR returns the cbor encoding of the witness set.
+func (w *Witness) MarshalCBOR() ([]byte, error) {
+	type witness Witness
+	data, err := cbor.Marshal((*witness)(w))
+	if err != nil {
+		return nil, err
+	}
+	return w.unknown.merge(data)
+}
+
+// UnmarshalCBOR deserializes a cbor encoded witness set. Witnesses other than vkey witnesses are kept
+// in their encoding so they are not lost when the transaction is encoded again.
+func (w *Witness) UnmarshalCBOR(data []byte) error {
+	keys, values, err := unmarshalMap(data)
+	if err != nil {
+		return err
+	}
+
+	witness := Witness{}
+	for i := range keys {
+		var key uint64
+		if err := cbor.Unmarshal(keys[i], &key); err == nil && key == 0 {
+			if err := cbor.Unmarshal(values[i], &witness.Keys); err != nil {
+				return err
+			}
+			continue
+		}
+		witness.unknown.add(keys[i], values[i])
+	}
+
+	*w = witness
+	return nil
+}
+
 // VKeyWitness - Witness for use with Shelley based transactions
 type VKeyWitness struct {
 	_         struct{} `cbor:",toarray"`
@@ -31,6 +73,11 @@ func NewVKeyWitness(vkey, signature []byte) *VKeyWitness {
 	}
 }
 
+// Verify reports whether the signature of the witness is a valid signature of the transaction body hash by its key.
+func (w *VKeyWitness) Verify(txHash []byte) bool {
+	return bip32.PublicKey(w.VKey).Verify(txHash, w.Signature)
+}
+
 // BootstrapWitness for use with Byron/Legacy based transactions
 type BootstrapWitness struct {
 	_          struct{} `cbor:",toarray"`
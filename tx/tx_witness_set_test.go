@@ -0,0 +1,123 @@
+package tx_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/fivebinaries/go-cardano-serialization/network"
+	"github.com/fivebinaries/go-cardano-serialization/protocol"
+	"github.com/fivebinaries/go-cardano-serialization/tx"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyWitnesses(t *testing.T) {
+	addr, utxoPrv, err := generateBaseAddress(network.TestNet())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	builder := tx.NewTxBuilder(protocol.Protocol{TxFeePerByte: 44, TxFeeFixed: 155381}, []bip32.XPrv{utxoPrv})
+	builder.AddInputs(tx.NewTxInput("fcbc18c64cdf133f33dd319c5105dc7c4972f2d646ae276fbd00cf7f39f8c380", 0, 1000000000))
+	builder.AddChangeIfNeeded(addr)
+	txFinal, err := builder.Build()
+	assert.NoError(t, err)
+
+	invalid, err := txFinal.VerifyWitnesses()
+	assert.NoError(t, err)
+	assert.Empty(t, invalid)
+
+	// A co-signer witness over a different body is reported as invalid.
+	coSigner := createRootKey().Derive(harden(1852)).Derive(harden(1815)).Derive(harden(1)).Derive(0).Derive(0)
+	signature := coSigner.Sign([]byte("another body hash"))
+	forged := tx.NewVKeyWitness(coSigner.Public().PublicKey(), signature[:])
+	txFinal.Witness.Keys = append(txFinal.Witness.Keys, forged)
+
+	invalid, err = txFinal.VerifyWitnesses()
+	assert.NoError(t, err)
+	assert.Equal(t, []*tx.VKeyWitness{forged}, invalid)
+
+	txHash, err := txFinal.Hash()
+	assert.NoError(t, err)
+	signature = coSigner.Sign(txHash[:])
+	forged.Signature = signature[:]
+	invalid, err = txFinal.VerifyWitnesses()
+	assert.NoError(t, err)
+	assert.Empty(t, invalid)
+
+	// Changing the body invalidates all witnesses.
+	txFinal.SetFee(uint(txFinal.Body.Fee) + 1)
+	invalid, err = txFinal.VerifyWitnesses()
+	assert.NoError(t, err)
+	assert.Len(t, invalid, 2)
+}
+
+func TestVerifyDecodedWitnesses(t *testing.T) {
+	// A signed conway transaction encoded the way cardano-cli does, with tagged sets, a post-alonzo output,
+	// native tokens and a validity start. Encoding its fields again would not give the signed body.
+	txHex := "84a500d90102818258207a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a7a010182a200581d600c6d5d" +
+		"405d2e9a2122624963ea3ffeeb6570b31a790db08d6e3cd38d011a0016e360825839000c6d5d405d2e9a2122624963ea3ffeeb6570b31a79" +
+		"0db08d6e3cd38d5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e821a001e8480a1581cc3c3c3c3c3c3c3c3c3c3c3c3" +
+		"c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3a144434f494e05021a0002bf20031a02faf080081903e8a100d90102818258202543b92ff1095511" +
+		"476adc8369db6ddc933665a11978dda1404ee1066ca9559d5840b30b5102f898aa5a1dbd812b1f18119551e4f304c129369e66ca4955c091" +
+		"3073cb9f1e7ad3944e29e7eb72cb2fd4c3e7c91e369e943909dd40c3fb74a42c1c01f5f6"
+	data, err := hex.DecodeString(txHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := &tx.Tx{}
+	assert.NoError(t, cbor.Unmarshal(data, decoded))
+	assert.True(t, decoded.Valid)
+	assert.Nil(t, decoded.AuxiliaryData)
+	assert.Len(t, decoded.Body.Inputs, 1)
+	assert.Equal(t, uint16(1), decoded.Body.Inputs[0].Index)
+	assert.Len(t, decoded.Body.Outputs, 2)
+	assert.Equal(t, "addr_test1vqxx6h2qt5hf5gfzvfyk863llm4k2u9nrfusmvyddc7d8rgt793hn", decoded.Body.Outputs[0].Address.String())
+	assert.Equal(t, uint(1500000), decoded.Body.Outputs[0].Amount)
+	assert.Equal(t, uint(2000000), decoded.Body.Outputs[1].Amount)
+	assert.Equal(t, uint64(180000), decoded.Body.Fee)
+	assert.Equal(t, uint32(50000000), decoded.Body.TTL)
+
+	txHash, err := decoded.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, "463bf70ecf5b3210e66ac6966be41ae3cb1dec5c0cbdc83e0ec84de2015cf51e", hex.EncodeToString(txHash[:]))
+	invalid, err := decoded.VerifyWitnesses()
+	assert.NoError(t, err)
+	assert.Empty(t, invalid)
+
+	// Encoding the transaction again, e.g. after adding a witness, keeps the signed body.
+	encoded, err := decoded.Bytes()
+	assert.NoError(t, err)
+	redecoded := &tx.Tx{}
+	assert.NoError(t, cbor.Unmarshal(encoded, redecoded))
+	invalid, err = redecoded.VerifyWitnesses()
+	assert.NoError(t, err)
+	assert.Empty(t, invalid)
+
+	// Changing the body encodes its fields, keeping the tokens and the validity start, and invalidates the witness.
+	decoded.SetFee(200000)
+	invalid, err = decoded.VerifyWitnesses()
+	assert.NoError(t, err)
+	assert.Len(t, invalid, 1)
+	body, err := decoded.Body.Bytes()
+	assert.NoError(t, err)
+	assert.Contains(t, hex.EncodeToString(body), "a144434f494e05")
+	assert.Contains(t, hex.EncodeToString(body), "081903e8")
+
+	reencoded := &tx.TxBody{}
+	assert.NoError(t, cbor.Unmarshal(body, reencoded))
+	assert.Equal(t, uint64(200000), reencoded.Fee)
+	// Native scripts and other witnesses are kept when the witness set is encoded again.
+	scriptWitnesses, err := hex.DecodeString("a10181" + "8200581c" + "0c6d5d405d2e9a2122624963ea3ffeeb6570b31a790db08d6e3cd38d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	witness := &tx.Witness{}
+	assert.NoError(t, cbor.Unmarshal(scriptWitnesses, witness))
+	assert.Empty(t, witness.Keys)
+	encoded, err = cbor.Marshal(witness)
+	assert.NoError(t, err)
+	assert.Equal(t, scriptWitnesses, encoded)
+}
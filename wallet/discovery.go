@@ -0,0 +1,155 @@
+package wallet
+
+import (
+	"errors"
+
+	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/network"
+	"github.com/fivebinaries/go-cardano-serialization/node"
+)
+
+var ErrNoAddressHistory = errors.New("node does not index the transactions of addresses, use NewUTxOUsageSource")
+
+// DefaultGapLimit is the BIP-44 number of consecutive unused addresses after which discovery stops.
+const DefaultGapLimit = 20
+
+// UsageSource reports which addresses have been used on chain.
+type UsageSource interface {
+	// UsedAddresses returns whether each of the addresses has been used.
+	UsedAddresses(addrs []address.Address) ([]bool, error)
+}
+
+// UsageSourceFunc is a function implementing UsageSource.
+type UsageSourceFunc func(addrs []address.Address) ([]bool, error)
+
+// UsedAddresses calls f(addrs).
+func (f UsageSourceFunc) UsedAddresses(addrs []address.Address) ([]bool, error) {
+	return f(addrs)
+}
+
+// NewNodeUsageSource returns the UsageSource of the transaction history of the node, e.g. of
+// node.NewBlockfrostClient, or ErrNoAddressHistory if the node does not index the transactions of addresses.
+func NewNodeUsageSource(n node.Node) (UsageSource, error) {
+	history, ok := n.(node.AddressHistory)
+	if !ok {
+		return nil, ErrNoAddressHistory
+	}
+	return NewHistoryUsageSource(history), nil
+}
+
+// NewHistoryUsageSource returns a UsageSource treating addresses with any transaction as used.
+func NewHistoryUsageSource(h node.AddressHistory) UsageSource {
+	return UsageSourceFunc(func(addrs []address.Address) ([]bool, error) {
+		used := make([]bool, len(addrs))
+		for i, addr := range addrs {
+			var err error
+			if used[i], err = h.HasTransactions(addr); err != nil {
+				return nil, err
+			}
+		}
+		return used, nil
+	})
+}
+
+// NewUTxOUsageSource returns a UsageSource treating addresses holding utxos on the node as used, a fallback
+// for nodes without the transaction history of addresses, e.g. cardano-cli. Addresses whose outputs were all
+// spent are reported unused, so discovery may stop before the last used address.
+func NewUTxOUsageSource(n node.Node) UsageSource {
+	return UsageSourceFunc(func(addrs []address.Address) ([]bool, error) {
+		used := make([]bool, len(addrs))
+		for i, addr := range addrs {
+			utxos, err := n.UTXOs(addr)
+			if err != nil {
+				return nil, err
+			}
+			used[i] = len(utxos) > 0
+		}
+		return used, nil
+	})
+}
+
+// DiscoveredAddress is a used address of an account with the role and index of its payment key.
+type DiscoveredAddress struct {
+	Address *address.BaseAddress
+	Role    KeyRole
+	Index   uint32
+}
+
+// Discovery finds the used base addresses of the external and internal chains of an account.
+type Discovery struct {
+	Account *Account
+	Network *network.NetworkInfo
+	Source  UsageSource
+
+	// GapLimit is the number of consecutive unused addresses after which a chain is exhausted.
+	GapLimit uint32
+	// BatchSize is the number of addresses queried from Source at once, GapLimit if zero.
+	BatchSize uint32
+	// StakeIndex is the index of the stake key of the addresses.
+	StakeIndex uint32
+}
+
+// NewDiscovery returns a pointer to a new Discovery of the account with the default gap limit.
+func NewDiscovery(account *Account, net *network.NetworkInfo, source UsageSource) *Discovery {
+	return &Discovery{
+		Account:  account,
+		Network:  net,
+		Source:   source,
+		GapLimit: DefaultGapLimit,
+	}
+}
+
+// Discover returns the used addresses of the external chain followed by the internal chain, in index order.
+func (d *Discovery) Discover() ([]DiscoveredAddress, error) {
+	var discovered []DiscoveredAddress
+	for _, role := range []KeyRole{ExternalRole, InternalRole} {
+		addrs, err := d.discoverChain(role)
+		if err != nil {
+			return nil, err
+		}
+		discovered = append(discovered, addrs...)
+	}
+	return discovered, nil
+}
+
+// discoverChain queries the addresses of the role in batches until GapLimit consecutive addresses are unused.
+func (d *Discovery) discoverChain(role KeyRole) ([]DiscoveredAddress, error) {
+	gapLimit := d.GapLimit
+	if gapLimit == 0 {
+		gapLimit = DefaultGapLimit
+	}
+	batchSize := d.BatchSize
+	if batchSize == 0 {
+		batchSize = gapLimit
+	}
+
+	var discovered []DiscoveredAddress
+	var gap uint32
+	for start := uint32(0); ; start += batchSize {
+		batch := make([]*address.BaseAddress, batchSize)
+		addrs := make([]address.Address, batchSize)
+		for i := range batch {
+			addr, err := d.Account.BaseAddress(d.Network, role, start+uint32(i), d.StakeIndex)
+			if err != nil {
+				return nil, err
+			}
+			batch[i], addrs[i] = addr, addr
+		}
+
+		used, err := d.Source.UsedAddresses(addrs)
+		if err != nil {
+			return nil, err
+		}
+		for i, addr := range batch {
+			if i >= len(used) || !used[i] {
+				gap++
+				if gap >= gapLimit {
+					return discovered, nil
+				}
+				continue
+			}
+			gap = 0
+			discovered = append(discovered, DiscoveredAddress{Address: addr, Role: role, Index: start + uint32(i)})
+		}
+	}
+}
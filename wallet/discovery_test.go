@@ -0,0 +1,123 @@
+package wallet_test
+
+import (
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/network"
+	"github.com/fivebinaries/go-cardano-serialization/node"
+	"github.com/fivebinaries/go-cardano-serialization/tx"
+	"github.com/fivebinaries/go-cardano-serialization/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+// usedSource reports the addresses of the used set as used and counts the queries.
+type usedSource struct {
+	used    map[string]bool
+	queries int
+}
+
+func (s *usedSource) UsedAddresses(addrs []address.Address) ([]bool, error) {
+	s.queries++
+	used := make([]bool, len(addrs))
+	for i, addr := range addrs {
+		used[i] = s.used[addr.String()]
+	}
+	return used, nil
+}
+
+func newUsedSource(t *testing.T, account *wallet.Account, paths map[wallet.KeyRole][]uint32) *usedSource {
+	source := &usedSource{used: map[string]bool{}}
+	for role, indices := range paths {
+		for _, index := range indices {
+			addr, err := account.BaseAddress(network.MainNet(), role, index, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			source.used[addr.String()] = true
+		}
+	}
+	return source
+}
+
+func TestDiscovery(t *testing.T) {
+	account := createWallet(t).Account(0)
+	source := newUsedSource(t, account, map[wallet.KeyRole][]uint32{
+		wallet.ExternalRole: {0, 1, 5, 24},
+		wallet.InternalRole: {0, 2},
+	})
+
+	discovered, err := wallet.NewDiscovery(account, network.MainNet(), source).Discover()
+	assert.NoError(t, err)
+
+	var paths [][2]uint32
+	for _, addr := range discovered {
+		paths = append(paths, [2]uint32{uint32(addr.Role), addr.Index})
+		assert.True(t, source.used[addr.Address.String()])
+	}
+	assert.Equal(t, [][2]uint32{{0, 0}, {0, 1}, {0, 5}, {0, 24}, {1, 0}, {1, 2}}, paths)
+	// Indices 0-59 of the external chain and 0-39 of the internal chain in batches of 20.
+	assert.Equal(t, 5, source.queries)
+
+	// Index 24 is beyond a gap of 10 unused addresses.
+	source.queries = 0
+	discovery := wallet.NewDiscovery(account, network.MainNet(), source)
+	discovery.GapLimit = 10
+	discovery.BatchSize = 4
+	discovered, err = discovery.Discover()
+	assert.NoError(t, err)
+	assert.Len(t, discovered, 5)
+	assert.Equal(t, 8, source.queries)
+}
+
+// utxoNode is a node.Node holding utxos of the used addresses.
+type utxoNode struct {
+	node.Node
+	used map[string]bool
+}
+
+func (n *utxoNode) UTXOs(addr address.Address) ([]tx.TxInput, error) {
+	if !n.used[addr.String()] {
+		return nil, nil
+	}
+	return []tx.TxInput{*tx.NewTxInput("fcbc18c64cdf133f33dd319c5105dc7c4972f2d646ae276fbd00cf7f39f8c380", 0, 1000000)}, nil
+}
+
+// historyNode is a node.Node indexing the transactions of the used addresses, which hold no utxos.
+type historyNode struct {
+	node.Node
+	used map[string]bool
+}
+
+func (n *historyNode) UTXOs(addr address.Address) ([]tx.TxInput, error) {
+	return nil, nil
+}
+
+func (n *historyNode) HasTransactions(addr address.Address) (bool, error) {
+	return n.used[addr.String()], nil
+}
+
+func TestNodeUsageSource(t *testing.T) {
+	account := createWallet(t).Account(0)
+	source := newUsedSource(t, account, map[wallet.KeyRole][]uint32{wallet.InternalRole: {3}})
+
+	// Addresses whose outputs were all spent are found in the transaction history.
+	usage, err := wallet.NewNodeUsageSource(&historyNode{used: source.used})
+	assert.NoError(t, err)
+	discovered, err := wallet.NewDiscovery(account, network.MainNet(), usage).Discover()
+	assert.NoError(t, err)
+	assert.Len(t, discovered, 1)
+	assert.Equal(t, wallet.InternalRole, discovered[0].Role)
+	assert.Equal(t, uint32(3), discovered[0].Index)
+
+	discovered, err = wallet.NewDiscovery(account, network.MainNet(), wallet.NewUTxOUsageSource(&historyNode{used: source.used})).Discover()
+	assert.NoError(t, err)
+	assert.Empty(t, discovered)
+
+	_, err = wallet.NewNodeUsageSource(&utxoNode{used: source.used})
+	assert.Equal(t, wallet.ErrNoAddressHistory, err)
+	discovered, err = wallet.NewDiscovery(account, network.MainNet(), wallet.NewUTxOUsageSource(&utxoNode{used: source.used})).Discover()
+	assert.NoError(t, err)
+	assert.Len(t, discovered, 1)
+	assert.Equal(t, uint32(3), discovered[0].Index)
+}
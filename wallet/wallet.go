@@ -0,0 +1,185 @@
+// Package wallet derives the keys and addresses of CIP-1852 hierarchical deterministic wallets,
+// m / 1852' / 1815' / account' / role / index.
+package wallet
+
+import (
+	"errors"
+
+	"github.com/fivebinaries/go-cardano-serialization/address"
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fivebinaries/go-cardano-serialization/network"
+)
+
+const (
+	// Purpose is the CIP-1852 purpose index of Shelley era wallets.
+	Purpose uint32 = 1852
+	// CoinType is the SLIP-0044 coin type of ada.
+	CoinType uint32 = 1815
+)
+
+// KeyRole is the CIP-1852 role of the keys derived from an account.
+type KeyRole uint32
+
+const (
+	ExternalRole KeyRole = iota
+	InternalRole
+	StakeRole
+	DRepRole
+	CommitteeColdRole
+	CommitteeHotRole
+)
+
+var (
+	ErrWatchOnly   = errors.New("watch-only account has no private keys")
+	ErrKeyNotFound = errors.New("no key of the account matches the key hash")
+)
+
+// Roles are the key roles of an account in the order they are searched for key hashes.
+var Roles = []KeyRole{ExternalRole, InternalRole, StakeRole, DRepRole, CommitteeColdRole, CommitteeHotRole}
+
+// Harden returns the hardened derivation index of index.
+func Harden(index uint32) uint32 {
+	return index | bip32.HardenedIndex
+}
+
+// Wallet is a CIP-1852 wallet of a root private key.
+type Wallet struct {
+	root bip32.XPrv
+}
+
+// NewWallet returns a pointer to a new Wallet of the root key.
+func NewWallet(rootKey bip32.XPrv) *Wallet {
+	return &Wallet{root: rootKey}
+}
+
+// Account returns the account of the wallet at the index, derived at m/1852'/1815'/index'.
+func (w *Wallet) Account(index uint32) *Account {
+	return NewAccount(w.root.Derive(Harden(Purpose)).Derive(Harden(CoinType)).Derive(Harden(index)))
+}
+
+// Account derives the role keys and addresses of a CIP-1852 account. Accounts created from
+// an account public key are watch-only and cannot return private keys.
+type Account struct {
+	prv bip32.XPrv
+	pub bip32.XPub
+}
+
+// NewAccount returns a pointer to a new Account of the account private key.
+func NewAccount(accountKey bip32.XPrv) *Account {
+	return &Account{prv: accountKey, pub: accountKey.Public()}
+}
+
+// NewWatchOnlyAccount returns a pointer to a new Account of the account public key, e.g. an `acct_xvk`.
+func NewWatchOnlyAccount(accountKey bip32.XPub) *Account {
+	return &Account{pub: accountKey}
+}
+
+// IsWatchOnly reports whether the account has no private key.
+func (a *Account) IsWatchOnly() bool {
+	return a.prv == nil
+}
+
+// XPub returns the account public key.
+func (a *Account) XPub() bip32.XPub {
+	return a.pub
+}
+
+// PrivateKey returns the private key of the role at the index.
+func (a *Account) PrivateKey(role KeyRole, index uint32) (bip32.XPrv, error) {
+	if a.IsWatchOnly() {
+		return nil, ErrWatchOnly
+	}
+	return a.prv.Derive(uint32(role)).Derive(index), nil
+}
+
+// PublicKey returns the public key of the role at the index. Watch-only accounts only derive soft indices.
+func (a *Account) PublicKey(role KeyRole, index uint32) (bip32.XPub, error) {
+	if !a.IsWatchOnly() {
+		return a.prv.Derive(uint32(role)).Derive(index).Public(), nil
+	}
+
+	roleKey, err := a.pub.Derive(uint32(role))
+	if err != nil {
+		return nil, err
+	}
+	return roleKey.Derive(index)
+}
+
+// KeyHash returns the hash of the public key of the role at the index.
+func (a *Account) KeyHash(role KeyRole, index uint32) (crypto.Ed25519KeyHash, error) {
+	pub, err := a.PublicKey(role, index)
+	if err != nil {
+		return crypto.Ed25519KeyHash{}, err
+	}
+	return pub.PublicKey().Hash(), nil
+}
+
+// Credential returns the key credential of the role at the index.
+func (a *Account) Credential(role KeyRole, index uint32) (*address.StakeCredential, error) {
+	hash, err := a.KeyHash(role, index)
+	if err != nil {
+		return nil, err
+	}
+	return address.NewKeyStakeCredential(hash[:]), nil
+}
+
+// BaseAddress returns the base address of the payment key of the role at the index,
+// delegated to the stake key at stakeIndex.
+func (a *Account) BaseAddress(net *network.NetworkInfo, role KeyRole, index, stakeIndex uint32) (*address.BaseAddress, error) {
+	payment, err := a.Credential(role, index)
+	if err != nil {
+		return nil, err
+	}
+	stake, err := a.Credential(StakeRole, stakeIndex)
+	if err != nil {
+		return nil, err
+	}
+	return address.NewBaseAddress(net, payment, stake), nil
+}
+
+// EnterpriseAddress returns the enterprise address of the payment key of the role at the index.
+func (a *Account) EnterpriseAddress(net *network.NetworkInfo, role KeyRole, index uint32) (*address.EnterpriseAddress, error) {
+	payment, err := a.Credential(role, index)
+	if err != nil {
+		return nil, err
+	}
+	return address.NewEnterpriseAddress(net, payment), nil
+}
+
+// RewardAddress returns the reward address of the stake key at the index.
+func (a *Account) RewardAddress(net *network.NetworkInfo, index uint32) (*address.RewardAddress, error) {
+	stake, err := a.Credential(StakeRole, index)
+	if err != nil {
+		return nil, err
+	}
+	return address.NewRewardAddress(net, stake), nil
+}
+
+// FindKey returns the role and index of the key of the key hash, searching the first limit indices of every role.
+func (a *Account) FindKey(keyHash crypto.Ed25519KeyHash, limit uint32) (KeyRole, uint32, error) {
+	for _, role := range Roles {
+		for index := uint32(0); index < limit; index++ {
+			hash, err := a.KeyHash(role, index)
+			if err != nil {
+				return 0, 0, err
+			}
+			if hash == keyHash {
+				return role, index, nil
+			}
+		}
+	}
+	return 0, 0, ErrKeyNotFound
+}
+
+// SigningKey returns the private key of the key hash, searching the first limit indices of every role.
+func (a *Account) SigningKey(keyHash crypto.Ed25519KeyHash, limit uint32) (bip32.XPrv, error) {
+	if a.IsWatchOnly() {
+		return nil, ErrWatchOnly
+	}
+	role, index, err := a.FindKey(keyHash, limit)
+	if err != nil {
+		return nil, err
+	}
+	return a.PrivateKey(role, index)
+}
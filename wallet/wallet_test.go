@@ -0,0 +1,74 @@
+package wallet_test
+
+import (
+	"testing"
+
+	"github.com/fivebinaries/go-cardano-serialization/bip32"
+	"github.com/fivebinaries/go-cardano-serialization/crypto"
+	"github.com/fivebinaries/go-cardano-serialization/network"
+	"github.com/fivebinaries/go-cardano-serialization/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+func createWallet(t *testing.T) *wallet.Wallet {
+	rootKey, err := bip32.FromMnemonic("test walk nut penalty hip pave soap entry language right filter choice", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return wallet.NewWallet(rootKey)
+}
+
+func TestAccountAddresses(t *testing.T) {
+	account := createWallet(t).Account(0)
+	watchOnly := wallet.NewWatchOnlyAccount(account.XPub())
+
+	for _, acc := range []*wallet.Account{account, watchOnly} {
+		// CIP-19 test vector.
+		enterprise, err := acc.EnterpriseAddress(network.MainNet(), wallet.ExternalRole, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8", enterprise.String())
+
+		base, err := acc.BaseAddress(network.TestNet(), wallet.ExternalRole, 0, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "addr_test1qz2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3jcu5d8ps7zex2k2xt3uqxgjqnnj83ws8lhrn648jjxtwq2ytjqp", base.String())
+
+		reward, err := acc.RewardAddress(network.MainNet(), 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "stake1uyevw2xnsc0pvn9t9r9c7qryfqfeerchgrlm3ea2nefr9hqxdekzz", reward.String())
+	}
+
+	change, err := account.BaseAddress(network.MainNet(), wallet.InternalRole, 3, 0)
+	assert.NoError(t, err)
+	watchOnlyChange, err := watchOnly.BaseAddress(network.MainNet(), wallet.InternalRole, 3, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, change.String(), watchOnlyChange.String())
+
+	_, err = watchOnly.PublicKey(wallet.ExternalRole, wallet.Harden(0))
+	assert.ErrorIs(t, err, bip32.ErrHardenedPublicDerivation)
+	_, err = watchOnly.PrivateKey(wallet.ExternalRole, 0)
+	assert.ErrorIs(t, err, wallet.ErrWatchOnly)
+}
+
+func TestAccountSigningKey(t *testing.T) {
+	account := createWallet(t).Account(1)
+
+	for _, role := range wallet.Roles {
+		keyHash, err := account.KeyHash(role, 4)
+		assert.NoError(t, err)
+
+		foundRole, index, err := account.FindKey(keyHash, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, role, foundRole)
+		assert.Equal(t, uint32(4), index)
+
+		key, err := account.SigningKey(keyHash, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, keyHash, key.Public().PublicKey().Hash())
+
+		_, err = account.SigningKey(keyHash, 4)
+		assert.ErrorIs(t, err, wallet.ErrKeyNotFound)
+	}
+
+	_, err := wallet.NewWatchOnlyAccount(account.XPub()).SigningKey(crypto.Ed25519KeyHash{}, 1)
+	assert.ErrorIs(t, err, wallet.ErrWatchOnly)
+}